@@ -0,0 +1,175 @@
+package decimal
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parses amounts as they appear in bank exports and spreadsheets, like "(1,234.50)", "$ -12.00", "12.00 CR",
+// "EUR 1 234,56" or "1_000_000".
+//
+// The number is parsed with ParseLocale using the Locale (LocaleUS if its Decimal separator is empty),
+// also accepting underscores and spaces as grouping separators. Around the number it accepts:
+//   - A currency symbol or code before or after the number, which is returned separately.
+//     If Currencies is empty any currency symbol (like "$" or "€") or three uppercase letters (like "EUR") are accepted
+//   - A negative sign before or after the number (or the currency), or parentheses around the whole amount
+//   - A "CR" (negative) or "DR" (positive) suffix, ignoring case
+type AmountParser struct {
+	Locale     Locale
+	Currencies []string
+}
+
+// Parse an amount from a given string, returning the number and the currency (empty if there was none).
+// Returns a *ParseError if the string is not a well-formed amount, including when it has more than one negative marker
+//
+// Examples:
+//  1. "(1,234.50)" parses as -123450 * 10 ^ -2
+//  2. "$ -12.00" parses as -1200 * 10 ^ -2 and "$"
+//  3. "12.00 CR" parses as -1200 * 10 ^ -2
+//  4. "EUR 1 234,56" with LocaleDE parses as 123456 * 10 ^ -2 and "EUR"
+//  5. "-(12)" fails with "more than one negative marker" at offset 0
+func (p AmountParser) Parse(amount string) (d Decimal, currency string, err error) {
+	fail := func(offset int, reason string) (Decimal, string, error) {
+		return Decimal{}, "", &ParseError{Input: amount, Offset: offset, Reason: reason, Err: ErrorParsingSyntax}
+	}
+	// The amount is in amount[start:end]
+	start, end := 0, len(amount)
+	trim := func() {
+		start = end - len(strings.TrimLeftFunc(amount[start:end], unicode.IsSpace))
+		end = start + len(strings.TrimRightFunc(amount[start:end], unicode.IsSpace))
+	}
+	negative := 0
+	parentheses, credit, currencyDone, sign, trailingSign := false, false, false, false, false
+	trim()
+	// Remove what's around the number, in any order
+	for changed := true; changed && start < end; {
+		prefix, suffix, creditOrDebit := p.currencyPrefix(amount[start:end]), p.currencySuffix(amount[start:end]), p.creditSuffix(amount[start:end])
+		switch {
+		case !parentheses && end-start >= 2 && amount[start] == '(' && amount[end-1] == ')':
+			parentheses = true
+			negative++
+			start, end = start+1, end-1
+		case !credit && creditOrDebit != 0:
+			credit = true
+			if creditOrDebit < 0 {
+				negative++
+			}
+			end -= 2
+		case !currencyDone && prefix != "":
+			currencyDone, currency = true, prefix
+			start += len(currency)
+		case !currencyDone && suffix != "":
+			currencyDone, currency = true, suffix
+			end -= len(currency)
+		case !sign && (amount[start] == '-' || amount[start] == '+'):
+			sign = true
+			if amount[start] == '-' {
+				negative++
+			}
+			start++
+		case !trailingSign && amount[end-1] == '-':
+			trailingSign = true
+			negative++
+			end--
+		default:
+			changed = false
+		}
+		trim()
+	}
+	switch {
+	case negative > 1:
+		return fail(0, "more than one negative marker")
+	case start < end && (amount[start] == '-' || amount[start] == '+'):
+		return fail(start, "unexpected sign")
+	}
+	// Number
+	d, err = ParseLocale(amount[start:end], p.locale())
+	if parseError, ok := err.(*ParseError); ok {
+		// Report the error for the whole amount
+		parseError.Input, parseError.Offset = amount, start+parseError.Offset
+		return Decimal{}, "", err
+	}
+	if negative == 1 {
+		d.Sign = false
+	}
+	return d, currency, nil
+}
+
+// Returns -1 if the amount ends with "CR" (ignoring case) after a space or a digit, +1 if it ends with "DR", 0 otherwise
+func (p AmountParser) creditSuffix(amount string) int {
+	if len(amount) <= 2 {
+		return 0
+	}
+	if before, _ := utf8.DecodeLastRuneInString(amount[:len(amount)-2]); !unicode.IsSpace(before) && !unicode.IsDigit(before) {
+		return 0
+	}
+	switch suffix := amount[len(amount)-2:]; {
+	case strings.EqualFold(suffix, "CR"):
+		return -1
+	case strings.EqualFold(suffix, "DR"):
+		return 1
+	}
+	return 0
+}
+
+// Returns the locale used to parse the number, which also accepts underscores and spaces as grouping separators
+func (p AmountParser) locale() Locale {
+	loc := p.Locale
+	if loc.Decimal == "" {
+		loc = LocaleUS
+	}
+	loc.Group = append(append([]string{}, loc.Group...), "_", " ")
+	return loc
+}
+
+// Returns the currency at the start of the amount, or an empty string
+func (p AmountParser) currencyPrefix(amount string) string {
+	if len(p.Currencies) > 0 {
+		match := ""
+		for _, currency := range p.Currencies {
+			if len(currency) > len(match) && strings.HasPrefix(amount, currency) {
+				match = currency
+			}
+		}
+		return match
+	}
+	if symbol, size := utf8.DecodeRuneInString(amount); unicode.Is(unicode.Sc, symbol) {
+		return amount[:size]
+	}
+	if isCurrencyCode(amount, 0) && (len(amount) == 3 || !isUpperLetter(amount[3])) {
+		return amount[:3]
+	}
+	return ""
+}
+
+// Returns the currency at the end of the amount, or an empty string
+func (p AmountParser) currencySuffix(amount string) string {
+	if len(p.Currencies) > 0 {
+		match := ""
+		for _, currency := range p.Currencies {
+			if len(currency) > len(match) && strings.HasSuffix(amount, currency) {
+				match = currency
+			}
+		}
+		return match
+	}
+	if symbol, size := utf8.DecodeLastRuneInString(amount); unicode.Is(unicode.Sc, symbol) {
+		return amount[len(amount)-size:]
+	}
+	if isCurrencyCode(amount, len(amount)-3) && (len(amount) == 3 || !isUpperLetter(amount[len(amount)-4])) {
+		return amount[len(amount)-3:]
+	}
+	return ""
+}
+
+// Returns true if amount[start:start+3] are three uppercase letters, like an ISO 4217 code
+func isCurrencyCode(amount string, start int) bool {
+	return start >= 0 && start+3 <= len(amount) &&
+		isUpperLetter(amount[start]) && isUpperLetter(amount[start+1]) && isUpperLetter(amount[start+2])
+}
+
+// Returns true if c is an uppercase ASCII letter
+func isUpperLetter(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
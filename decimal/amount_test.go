@@ -0,0 +1,103 @@
+package decimal_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestAmountParser(t *testing.T) {
+	us, de := decimal.AmountParser{}, decimal.AmountParser{Locale: decimal.LocaleDE}
+	custom := decimal.AmountParser{Locale: decimal.LocaleCH, Currencies: []string{"CHF", "Fr.", "R$"}}
+	testCases := map[struct {
+		amount string
+		parser *decimal.AmountParser
+	}]struct {
+		decimal  decimal.Decimal
+		currency string
+		offset   int
+		err      error
+	}{
+		{"(1,234.50)", &us}:       {decimal.Decimal{Sign: false, Value: 123450, PowerOfTen: -2}, "", 0, nil},
+		{"$ -12.00", &us}:         {decimal.Decimal{Sign: false, Value: 1200, PowerOfTen: -2}, "$", 0, nil},
+		{"-$12.00", &us}:          {decimal.Decimal{Sign: false, Value: 1200, PowerOfTen: -2}, "$", 0, nil},
+		{"12.00-", &us}:           {decimal.Decimal{Sign: false, Value: 1200, PowerOfTen: -2}, "", 0, nil},
+		{"12.00 CR", &us}:         {decimal.Decimal{Sign: false, Value: 1200, PowerOfTen: -2}, "", 0, nil},
+		{"12.00cr", &us}:          {decimal.Decimal{Sign: false, Value: 1200, PowerOfTen: -2}, "", 0, nil},
+		{"12.00 DR", &us}:         {decimal.Decimal{Sign: true, Value: 1200, PowerOfTen: -2}, "", 0, nil},
+		{" +12 ", &us}:            {decimal.Decimal{Sign: true, Value: 12}, "", 0, nil},
+		{"1_000_000", &us}:        {decimal.Decimal{Sign: true, Value: 1000000}, "", 0, nil},
+		{"1 000 000.5", &us}:      {decimal.Decimal{Sign: true, Value: 10000005, PowerOfTen: -1}, "", 0, nil},
+		{"USD 1,234", &us}:        {decimal.Decimal{Sign: true, Value: 1234}, "USD", 0, nil},
+		{"12 IDR", &us}:           {decimal.Decimal{Sign: true, Value: 12}, "IDR", 0, nil},
+		{"(€ 1,234.5) DR", &us}:   {decimal.Decimal{Sign: false, Value: 12345, PowerOfTen: -1}, "€", 0, nil},
+		{"12 USD-", &us}:          {decimal.Decimal{Sign: false, Value: 12}, "USD", 0, nil},
+		{"(0.00)", &us}:           {decimal.Decimal{Sign: false, Value: 0, PowerOfTen: -2}, "", 0, nil},
+		{"EUR 1 234,56", &de}:     {decimal.Decimal{Sign: true, Value: 123456, PowerOfTen: -2}, "EUR", 0, nil},
+		{"-1.234,56 €", &de}:      {decimal.Decimal{Sign: false, Value: 123456, PowerOfTen: -2}, "€", 0, nil},
+		{"CHF 1'234.50", &custom}: {decimal.Decimal{Sign: true, Value: 123450, PowerOfTen: -2}, "CHF", 0, nil},
+		{"R$ 12", &custom}:        {decimal.Decimal{Sign: true, Value: 12}, "R$", 0, nil},
+		{"12 Fr.", &custom}:       {decimal.Decimal{Sign: true, Value: 12}, "Fr.", 0, nil},
+		{"", &us}:                 {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+		{"$", &us}:                {decimal.Decimal{}, "", 1, decimal.ErrorParsingSyntax},
+		{"-(12)", &us}:            {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+		{"(12) CR", &us}:          {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+		{"-12-", &us}:             {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+		{"--12", &us}:             {decimal.Decimal{}, "", 1, decimal.ErrorParsingSyntax},
+		{"(12", &us}:              {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+		{"$12 USD", &us}:          {decimal.Decimal{}, "", 3, decimal.ErrorParsingGrouping},
+		{"12usd", &us}:            {decimal.Decimal{}, "", 2, decimal.ErrorParsingSyntax},
+		{"1,23.4", &us}:           {decimal.Decimal{}, "", 1, decimal.ErrorParsingGrouping},
+		{"1_000,000", &us}:        {decimal.Decimal{}, "", 5, decimal.ErrorParsingGrouping},
+		{"$ 12", &custom}:         {decimal.Decimal{}, "", 0, decimal.ErrorParsingSyntax},
+	}
+	for test, expected := range testCases {
+		d, currency, err := test.parser.Parse(test.amount)
+		if expected.err == nil {
+			if err != nil || d != expected.decimal || currency != expected.currency {
+				t.Fatalf("Parse(%q) expected (%v, %q), instead got (%v, %q, %v)", test.amount, expected.decimal, expected.currency, d, currency, err)
+			}
+			continue
+		}
+		var parseError *decimal.ParseError
+		if !errors.As(err, &parseError) || !errors.Is(err, expected.err) || parseError.Offset != expected.offset || parseError.Input != test.amount {
+			t.Fatalf("Parse(%q) expected %v at offset %d, instead got (%v, %q, %v)", test.amount, expected.err, expected.offset, d, currency, err)
+		}
+	}
+}
+
+func BenchmarkAmountParser(b *testing.B) {
+	parser := decimal.AmountParser{}
+	for i := 0; i < b.N; i++ {
+		parser.Parse("(USD 1,234.50)")
+	}
+}
+
+func FuzzAmountParser(f *testing.F) {
+	seeds := []string{"(1,234.50)", "$ -12.00", "12.00 CR", "EUR 1 234,56", "1_000_000", "-(12)", "12 USD-"}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	parser := decimal.AmountParser{}
+	f.Fuzz(func(t *testing.T, amount string) {
+		d, currency, err := parser.Parse(amount)
+		if err != nil {
+			var parseError *decimal.ParseError
+			if !errors.As(err, &parseError) || parseError.Offset < 0 || parseError.Offset > len(amount) {
+				t.Fatalf("Parse(%q) returned an invalid error: %v", amount, err)
+			}
+			return
+		}
+		if currency != "" && !strings.Contains(amount, currency) {
+			t.Fatalf("Parse(%q) returned the currency %q, which is not in the amount", amount, currency)
+		}
+		// Parsing the same amount in parentheses negates it (unless it's already negative)
+		if d.Sign && !strings.HasSuffix(strings.TrimSpace(amount), ")") {
+			if negative, _, err := parser.Parse("(" + amount + ")"); err == nil && (negative.Sign || negative.Value != d.Value || negative.PowerOfTen != d.PowerOfTen) {
+				t.Fatalf("Parse(%q) returned %v, but in parentheses returned %v", amount, d, negative)
+			}
+		}
+	})
+}
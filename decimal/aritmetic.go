@@ -1,10 +1,63 @@
 package decimal
 
 import (
-	"math"
+	"errors"
+	"math/bits"
 )
 
+var (
+	ErrDivisionByZero = errors.New("a non-zero number was divided by zero")
+	ErrIndeterminate  = errors.New("the result of the operation is indeterminate (for example 0/0)")
+	ErrOverflow       = errors.New("the result is too large to be represented")
+	ErrUnderflow      = errors.New("the result is too small to be represented exactly")
+)
+
+// Returns the sum x + y rounded with RoundHalfEven to as many digits as fit in a Decimal.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow
+func Add(x, y Decimal) (Decimal, error) {
+	result, cond := add(x, y, Context{})
+	return result, conditionError(cond)
+}
+
+// Returns the difference x - y rounded with RoundHalfEven to as many digits as fit in a Decimal.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow
+func Sub(x, y Decimal) (Decimal, error) {
+	y.Sign = !y.Sign
+	return Add(x, y)
+}
+
+// Returns the product x * y rounded with RoundHalfEven to as many digits as fit in a Decimal.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow
+func Mult(x, y Decimal) (Decimal, error) {
+	result, cond := mult(x, y, Context{})
+	return result, conditionError(cond)
+}
+
+// Returns the quotient x / y rounded with RoundHalfEven to as many digits as fit in a Decimal.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow.
+// Returns ±infinity and ErrDivisionByZero if y is zero, or NaN and ErrIndeterminate if both x and y are zero
+func Div(x, y Decimal) (Decimal, error) {
+	result, cond := div(x, y, Context{})
+	return result, conditionError(cond)
+}
+
+// Returns the error matching the most severe of the given conditions, or nil if none is an error
+func conditionError(cond Condition) error {
+	switch {
+	case cond&ConditionInvalidOperation != 0:
+		return ErrIndeterminate
+	case cond&ConditionDivisionByZero != 0:
+		return ErrDivisionByZero
+	case cond&ConditionOverflow != 0:
+		return ErrOverflow
+	case cond&ConditionUnderflow != 0:
+		return ErrUnderflow
+	}
+	return nil
+}
+
 // Perform the addition x + y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
 // If the decimal is nil, this operation will be a noop.
 // If the operation overflows/underflows, will return false
 func (d *Decimal) Add(x, y Decimal) (ok bool) {
@@ -12,104 +65,13 @@ func (d *Decimal) Add(x, y Decimal) (ok bool) {
 	if d == nil {
 		return false // NOOP
 	}
-	// Check if this is a subtraction
-	sameSign := true
-	if x.Sign != y.Sign {
-		sameSign = false
-	}
-	// Reset n
-	d.Sign = true
-	d.Value = 0
-	d.PowerOfTen = 0
-	// Get x, y on the same power of 10
-	x.Compress()
-	y.Compress()
-	if x.PowerOfTen != y.PowerOfTen {
-		powerHigh := &x
-		powerLow := &y
-		if x.PowerOfTen < y.PowerOfTen {
-			powerHigh = &y
-			powerLow = &x
-		}
-		// Increase the value of the higher to decrease its power of 10
-		for x.PowerOfTen != y.PowerOfTen && !overflow_multiplication(powerHigh.Value, 10) {
-			powerHigh.Value *= 10
-			powerHigh.PowerOfTen--
-		}
-		// [Loss of precision!] Decrease the value of the lower to increase its power of 10
-		for x.PowerOfTen != y.PowerOfTen && powerLow.Value >= 10 {
-			powerLow.Value /= 10
-			powerLow.PowerOfTen++
-		}
-		// Check if lower went to zero
-		if x.PowerOfTen != y.PowerOfTen {
-			// The lower number is so small that it's insignificant
-			d.Sign = powerHigh.Sign
-			d.Value = powerHigh.Value
-			d.PowerOfTen = powerHigh.PowerOfTen
-			return true
-		}
-	}
-	// Perform operation, being mindful of overflow
-	if sameSign {
-		if overflow_sum(x.Value, y.Value) {
-			if x.PowerOfTen == math.MaxInt64 || y.PowerOfTen == math.MaxInt64 {
-				// Overflow
-				d.Sign = x.Sign
-				d.Value = math.MaxUint64
-				d.PowerOfTen = math.MaxInt64
-				return false
-			}
-			// If we'll overflow, knock the value down, if we can
-			if x.Value < 10 {
-				d.Sign = y.Sign
-				d.Value = y.Value/10 + 1 // Add back remainder
-				d.PowerOfTen = y.PowerOfTen + 1
-				return true
-			}
-			if y.Value < 10 {
-				d.Sign = x.Sign
-				d.Value = x.Value/10 + 1
-				d.PowerOfTen = x.PowerOfTen + 1
-				return true
-			}
-			x.PowerOfTen++
-			y.PowerOfTen++
-			x.Value /= 10
-			y.Value /= 10
-		}
-		d.Sign = x.Sign
-		d.Value = x.Value + y.Value
-		d.PowerOfTen = x.PowerOfTen
-	} else if !x.Sign {
-		// y + -x
-		if overflow_subtraction(y.Value, x.Value) {
-			// Invert
-			d.Sign = x.Sign
-			d.Value = x.Value - y.Value
-			d.PowerOfTen = y.PowerOfTen
-			return true
-		}
-		d.Sign = y.Sign
-		d.Value = y.Value - x.Value
-		d.PowerOfTen = y.PowerOfTen
-	} else {
-		// x + -y
-		if overflow_subtraction(x.Value, y.Value) {
-			// Invert
-			d.Sign = y.Sign
-			d.Value = y.Value - x.Value
-			d.PowerOfTen = x.PowerOfTen
-			return true
-		}
-		d.Sign = x.Sign
-		d.Value = x.Value - y.Value
-		d.PowerOfTen = x.PowerOfTen
-	}
-	return true
+	var cond Condition
+	*d, cond = add(x, y, Context{})
+	return cond&^(ConditionInexact|ConditionRounded) == 0
 }
 
 // Perform the subtraction x - y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
 // If the decimal is nil, this operation will be a noop.
 // If the operation overflows/underflows, will return false
 func (d *Decimal) Sub(x, y Decimal) (ok bool) {
@@ -118,6 +80,7 @@ func (d *Decimal) Sub(x, y Decimal) (ok bool) {
 }
 
 // Perform the multiplication x * y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
 // If the decimal is nil, this operation will be a noop.
 // If the operation overflows/underflows, will return false
 func (d *Decimal) Mult(x, y Decimal) (ok bool) {
@@ -125,109 +88,296 @@ func (d *Decimal) Mult(x, y Decimal) (ok bool) {
 	if d == nil {
 		return false // NOOP
 	}
-	// Reset n
-	d.Sign = x.Sign == y.Sign
-	d.Value = 0
-	d.PowerOfTen = 0
-	// Compress x, y, then adjust the two values (losing precision) until multiplication doesn't overflow
-	x.Compress()
-	y.Compress()
-	for overflow_multiplication(x.Value, y.Value) {
-		larger := &x
-		smaller := &y
-		if x.Value < y.Value {
-			larger = &y
-			smaller = &x
+	var cond Condition
+	*d, cond = mult(x, y, Context{})
+	return cond&^(ConditionInexact|ConditionRounded) == 0
+}
+
+// Performs the addition x + y, rounded according to the given context
+func add(x, y Decimal, ctx Context) (Decimal, Condition) {
+	// Fast path: the operands can be aligned and added in a uint64, so there's nothing to round.
+	// Note: NaN and infinities have a Value of 0
+	if x.Value != 0 && y.Value != 0 && ctx.Precision <= 0 && !ctx.ExponentLimits {
+		if result, ok := addUint64(x, y); ok {
+			return result, 0
 		}
-		// Let's try to decrease the larger first (if we can)
-		if larger.PowerOfTen < math.MaxInt64 {
-			// NOTE: The larger between x and y must be much larger than 10
-			larger.Value /= 10
-			larger.PowerOfTen++
-			continue // Done for this iteration
+	}
+	// Special case: NaN and infinities
+	if !x.IsFinite() || !y.IsFinite() {
+		switch {
+		case x.IsNaN() || y.IsNaN():
+			return NaN(), 0
+		case x.IsInf() && y.IsInf() && x.Sign != y.Sign:
+			// ∞ - ∞ = ?
+			return NaN(), ConditionInvalidOperation
+		case x.IsInf():
+			return Inf(x.Sign), 0
 		}
-		// Okay, then let's try to decrease the smaller value (if we can)
-		if smaller.Value >= 10 && smaller.PowerOfTen < math.MaxInt64 {
-			smaller.Value /= 10
-			smaller.PowerOfTen++
-			continue // Done for this iteration
+		return Inf(y.Sign), 0
+	}
+	// Special case: zero
+	if x.IsZero() && y.IsZero() {
+		return Decimal{Sign: x.Sign || y.Sign}, 0
+	} else if x.IsZero() {
+		return roundCoefficient(y.Sign, uint128{lo: y.Value}, false, newWidePower(y.PowerOfTen), ctx)
+	} else if y.IsZero() {
+		return roundCoefficient(x.Sign, uint128{lo: x.Value}, false, newWidePower(x.PowerOfTen), ctx)
+	}
+	// Make sure x is the number with the higher power of ten
+	if x.PowerOfTen < y.PowerOfTen {
+		x, y = y, x
+	}
+	// Bring x to the same power of ten as y, as long as it fits in 38 digits.
+	// Note: the subtraction can't overflow a uint64 since x.PowerOfTen >= y.PowerOfTen
+	delta := uint64(x.PowerOfTen) - uint64(y.PowerOfTen)
+	shift := uint64(len(powersOfTen128)) - 1 - uint128{lo: x.Value}.digits()
+	if delta < shift {
+		shift = delta
+	}
+	high := uint128{lo: x.Value}.shiftLeft(shift)
+	// If x is too large, discard the digits of y that would not matter anyway remembering if any was non-zero.
+	// This can only happen if x has 38 digits, so we'll have to round the result regardless.
+	low, sticky := uint128{lo: y.Value}, false
+	if shift < delta {
+		var last uint64
+		low, last, sticky = low.shiftDigits(delta - shift)
+		sticky = sticky || last != 0
+	}
+	power := newWidePower(x.PowerOfTen).sub(int64(shift))
+	// Perform operation
+	if x.Sign == y.Sign {
+		return roundCoefficient(x.Sign, high.add(low), sticky, power, ctx)
+	}
+	if high.cmp(low) < 0 {
+		// Note: low can only be larger if no digit was discarded
+		return roundCoefficient(y.Sign, low.sub(high), false, power, ctx)
+	}
+	result := high.sub(low)
+	if sticky {
+		// x - (y + ε) = (x - y - 1) + (1 - ε)
+		result = result.sub(uint128{lo: 1})
+	} else if result.isZero() {
+		return Decimal{Sign: true}, 0
+	}
+	return roundCoefficient(x.Sign, result, sticky, power, ctx)
+}
+
+// Returns the exact sum x + y of two non-zero numbers and true, or false if the operands
+// can't be aligned to the same power of ten or the result doesn't fit in a uint64
+func addUint64(x, y Decimal) (Decimal, bool) {
+	if x.PowerOfTen < y.PowerOfTen {
+		x, y = y, x
+	}
+	// Note: the subtraction can't overflow a uint64 since x.PowerOfTen >= y.PowerOfTen
+	delta := uint64(x.PowerOfTen) - uint64(y.PowerOfTen)
+	if delta >= uint64(len(powersOfTen)) {
+		return Decimal{}, false
+	}
+	hi, high := bits.Mul64(x.Value, powersOfTen[delta])
+	if hi != 0 {
+		return Decimal{}, false
+	}
+	if x.Sign == y.Sign {
+		sum, carry := bits.Add64(high, y.Value, 0)
+		return Decimal{Sign: x.Sign, Value: sum, PowerOfTen: y.PowerOfTen}, carry == 0
+	}
+	switch {
+	case high > y.Value:
+		return Decimal{Sign: x.Sign, Value: high - y.Value, PowerOfTen: y.PowerOfTen}, true
+	case high < y.Value:
+		return Decimal{Sign: y.Sign, Value: y.Value - high, PowerOfTen: y.PowerOfTen}, true
+	}
+	return Decimal{Sign: true}, true
+}
+
+// Performs the multiplication x * y, rounded according to the given context
+func mult(x, y Decimal, ctx Context) (Decimal, Condition) {
+	sign := x.Sign == y.Sign
+	// Fast path: the product fits in a uint64 and its power of ten in an int64, so there's nothing to round.
+	// Note: NaN and infinities have a Value of 0
+	if hi, lo := bits.Mul64(x.Value, y.Value); hi == 0 && lo != 0 && ctx.Precision <= 0 && !ctx.ExponentLimits {
+		if power := x.PowerOfTen + y.PowerOfTen; (power < x.PowerOfTen) == (y.PowerOfTen < 0) {
+			return Decimal{Sign: sign, Value: lo, PowerOfTen: power}, 0
 		}
-		// If we got here we can't further reduce x and y to process the multiplication (overflow)
-		d.Value = math.MaxUint64
-		d.PowerOfTen = math.MaxInt64
-		return false // Overflow
-	}
-	// Check power-of-tens overflow
-	if overflow_int64(x.PowerOfTen, y.PowerOfTen) {
-		if x.PowerOfTen < 0 {
-			// Overflow to -inf
-			d.Value = 0
-			d.PowerOfTen = math.MinInt64
-		} else {
-			// Positive to +inf
-			d.Value = math.MaxUint64
-			d.PowerOfTen = math.MaxInt64
+	}
+	// Special case: NaN and infinities
+	if !x.IsFinite() || !y.IsFinite() {
+		switch {
+		case x.IsNaN() || y.IsNaN():
+			return NaN(), 0
+		case x.IsZero() || y.IsZero():
+			// 0 * ∞ = ?
+			return NaN(), ConditionInvalidOperation
 		}
-		return false
+		return Inf(sign), 0
+	}
+	// Special case: zero
+	if x.IsZero() || y.IsZero() {
+		return Decimal{Sign: sign}, 0
 	}
-	// Perform multiplication
-	d.Value = x.Value * y.Value
-	d.PowerOfTen = x.PowerOfTen + y.PowerOfTen
-	return true
+	hi, lo := bits.Mul64(x.Value, y.Value)
+	return roundCoefficient(sign, uint128{hi: hi, lo: lo}, false, newWidePower(x.PowerOfTen).add(y.PowerOfTen), ctx)
 }
 
 // Perform the division x / y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
 // If the decimal is nil, this operation will be a noop.
 // If the operation overflows/underflows, will return false
 // If a divide-by-zero error is encountered, will return false
 func (d *Decimal) Div(x, y Decimal) (ok bool) {
+	return d.DivRound(x, y, 0, RoundHalfEven)
+}
+
+// Perform the division x / y and store the result in this decimal, rounded with the given mode
+// to `precision` significant digits. A precision <= 0 keeps as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If a divide-by-zero error is encountered, will return false
+func (d *Decimal) DivRound(x, y Decimal, precision int, mode RoundingMode) (ok bool) {
 	// Special case: nil
 	if d == nil {
 		return false // NOOP
 	}
-	// Reset n
-	d.Sign = x.Sign == y.Sign
-	d.Value = 0
-	d.PowerOfTen = 0
+	var cond Condition
+	*d, cond = div(x, y, Context{Precision: precision, Rounding: mode})
+	return cond&^(ConditionInexact|ConditionRounded) == 0
+}
+
+// Performs the long division x / y, rounded according to the given context
+func div(x, y Decimal, ctx Context) (Decimal, Condition) {
+	sign := x.Sign == y.Sign
+	// Special case: NaN and infinities
+	if !x.IsFinite() || !y.IsFinite() {
+		switch {
+		case x.IsNaN() || y.IsNaN():
+			return NaN(), 0
+		case x.IsInf() && y.IsInf():
+			// ∞ / ∞ = ?
+			return NaN(), ConditionInvalidOperation
+		case x.IsInf():
+			return Inf(sign), 0
+		}
+		// 1 / ∞ = 0
+		return Decimal{Sign: sign}, 0
+	}
 	// Special case: divide by zero
 	if y.IsZero() {
-		// 0 / 0 = ?
-		if !x.IsZero() {
-			// 1 / 0 = infinity
-			d.Sign = true
-			d.Value = math.MaxUint64
-			d.PowerOfTen = math.MaxInt64
+		if x.IsZero() {
+			// 0 / 0 = ?
+			return NaN(), ConditionInvalidOperation
 		}
-		return false
+		// 1 / 0 = ∞
+		return Inf(sign), ConditionDivisionByZero
 	}
 	// Special case: divide zero
 	if x.IsZero() {
 		// 0 / 1 = 0
-		return true
-	}
-	// Prepare for division
-	// We want to do some tweaks here in order to be as precise result possible
-	x.Expand()   // Expand nominator
-	y.Compress() // Compress denominator
-	// We'd want x to be at least as big as y if at all possible
-	for x.Value < y.Value && math.MinInt64 < y.PowerOfTen {
-		y.PowerOfTen++
-		y.Value /= 10
-	}
-	// Check boundaries
-	for overflow_int64(x.PowerOfTen, -y.PowerOfTen) {
-		// Check if we can attempt to resolve this
-		if math.MinInt64 == y.PowerOfTen || y.Value < 10 {
-			// The result will be too small to process
-			d.PowerOfTen = math.MinInt64
-			return false
+		return Decimal{Sign: sign}, 0
+	}
+	// Long division, we keep generating digits (18 at a time) until we have
+	// at least one more digit than what can fit in a uint64 or the division is exact
+	quotient, remainder := uint128{lo: x.Value / y.Value}, x.Value%y.Value
+	power := newWidePower(x.PowerOfTen).sub(y.PowerOfTen)
+	for remainder != 0 && quotient.cmp(powersOfTen128[20]) < 0 {
+		// Note: since remainder < y.Value, the quotient of this step fits in a uint64
+		hi, lo := bits.Mul64(remainder, powersOfTen[18])
+		var digits uint64
+		digits, remainder = bits.Div64(hi, lo, y.Value)
+		quotient = quotient.mul64(powersOfTen[18]).add64(digits)
+		power = power.sub(18)
+	}
+	if remainder == 0 {
+		// The division is exact, remove the trailing zeroes added by the long division
+		ideal := newWidePower(x.PowerOfTen).sub(y.PowerOfTen)
+		for power.less(ideal) {
+			shorter, last := quotient.divmod64(10)
+			if last != 0 {
+				break
+			}
+			quotient, power = shorter, power.add(1)
 		}
-		// Attempt to resolve this
-		y.Value /= 10
-		y.PowerOfTen--
 	}
-	// Perform operation
-	d.PowerOfTen = x.PowerOfTen - y.PowerOfTen
-	d.Value = x.Value / y.Value
-	return true
+	return roundCoefficient(sign, quotient, remainder != 0, power, ctx)
+}
+
+// Returns the integer part of x / y (truncated towards zero) and the exact remainder x - q * y,
+// which has the same sign of x. The remainder always fits in a Decimal, while the quotient has to fit in
+// the Value of a Decimal with a PowerOfTen of 0:
+//   - if the quotient doesn't fit, q is ±infinity and r is NaN with ErrOverflow
+//   - if y is zero, q is ±infinity and r is NaN with ErrDivisionByZero, like Div
+//   - if x is infinite or both x and y are zero, q and r are NaN with ErrIndeterminate
+//
+// Examples:
+//   - QuoRem(7.5, 2): 3, 1.5
+//   - QuoRem(-7.5, 2): -3, -1.5
+//   - QuoRem(1, 0.3): 3, 0.1
+func QuoRem(x, y Decimal) (q, r Decimal, err error) {
+	q, r, cond := quoRem(x, y)
+	return q, r, conditionError(cond)
+}
+
+// Returns the remainder x - q * y where q is the integer part of x / y, see QuoRem.
+// The result has the same sign of x, like the % operator of Go
+func Rem(x, y Decimal) (Decimal, error) {
+	_, r, err := QuoRem(x, y)
+	return r, err
+}
+
+// Returns the modulo x - q * y where q is x / y rounded towards negative infinity.
+// The result has the same sign of y, like the % operator of Python.
+// Unlike Rem the result might need to be rounded (for example -1e-30 mod 7)
+//
+// Examples:
+//   - Mod(7.5, 2): 1.5
+//   - Mod(-7.5, 2): 0.5
+//   - Mod(7.5, -2): -0.5
+func Mod(x, y Decimal) (Decimal, error) {
+	_, r, cond := quoRem(x, y)
+	if cond == 0 && !r.IsZero() && r.Sign != y.Sign {
+		r, cond = add(r, y, Context{})
+	}
+	return r, conditionError(cond)
+}
+
+// Performs the integer division x / y returning the truncated quotient and the exact remainder
+func quoRem(x, y Decimal) (q, r Decimal, cond Condition) {
+	sign := x.Sign == y.Sign
+	// Special case: NaN and infinities
+	switch {
+	case x.IsNaN() || y.IsNaN():
+		return NaN(), NaN(), 0
+	case x.IsInf() || (x.IsZero() && y.IsZero()):
+		return NaN(), NaN(), ConditionInvalidOperation
+	case y.IsZero():
+		return Inf(sign), NaN(), ConditionDivisionByZero
+	case y.IsInf() || x.IsZero():
+		return Decimal{Sign: sign}, x, 0
+	}
+	// The quotient is xV * 10^e / yV with e = xP - yP
+	e := newWidePower(x.PowerOfTen).sub(y.PowerOfTen)
+	if e.hi < 0 {
+		// The remainder keeps the power of ten of x: xV mod (yV * 10^-e)
+		k := widePower{}.subWide(e)
+		divisor := uint128{lo: y.Value}
+		if k.hi == 0 && k.lo < uint64(len(powersOfTen)) {
+			divisor = divisor.mul64(powersOfTen[k.lo])
+		}
+		if k.hi != 0 || k.lo >= uint64(len(powersOfTen)) || divisor.hi != 0 {
+			// The divisor is larger than x
+			return Decimal{Sign: sign}, x, 0
+		}
+		return Decimal{Sign: sign, Value: x.Value / divisor.lo},
+			asFinite(Decimal{Sign: x.Sign, Value: x.Value % divisor.lo, PowerOfTen: x.PowerOfTen}), 0
+	}
+	// The remainder keeps the power of ten of y: (xV * 10^e) mod yV.
+	// Note: with e >= 39 the quotient is at least 10^39 / MaxUint64 so it can't fit
+	if e.hi != 0 || e.lo >= uint64(len(powersOfTen128)) {
+		return Inf(sign), NaN(), ConditionOverflow
+	}
+	quotient, remainder := uint128{lo: x.Value}.wide().shiftLeft(e.lo).divmod64(y.Value)
+	if quotient[1]|quotient[2]|quotient[3] != 0 {
+		return Inf(sign), NaN(), ConditionOverflow
+	}
+	return Decimal{Sign: sign, Value: quotient[0]},
+		asFinite(Decimal{Sign: x.Sign, Value: remainder, PowerOfTen: y.PowerOfTen}), 0
 }
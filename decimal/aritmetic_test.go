@@ -1,7 +1,9 @@
 package decimal_test
 
 import (
+	"errors"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stefanovazzocell/GoDecimal/decimal"
@@ -15,14 +17,14 @@ var sharedTestCases = map[struct{ x, y decimal.Decimal }]struct {
 		add:    decimal.Decimal{},
 		sub:    decimal.Decimal{},
 		mult:   decimal.Decimal{},
-		div:    decimal.Decimal{},
+		div:    decimal.NaN(),
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 	{decimal.Decimal{Value: 1}, decimal.Decimal{}}: {
 		add:    decimal.Decimal{Value: 1},
 		sub:    decimal.Decimal{Value: 1},
 		mult:   decimal.Decimal{},
-		div:    decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64},
+		div:    decimal.Inf(true),
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 	{decimal.Decimal{}, decimal.Decimal{Sign: true, Value: 1}}: {
@@ -36,14 +38,14 @@ var sharedTestCases = map[struct{ x, y decimal.Decimal }]struct {
 		add:    decimal.Decimal{},
 		sub:    decimal.Decimal{},
 		mult:   decimal.Decimal{},
-		div:    decimal.Decimal{Sign: true}, // 0/0
+		div:    decimal.NaN(), // 0/0
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 	{decimal.Decimal{}, decimal.Decimal{Sign: true}}: {
 		add:    decimal.Decimal{},
 		sub:    decimal.Decimal{},
 		mult:   decimal.Decimal{},
-		div:    decimal.Decimal{},
+		div:    decimal.NaN(),
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 	{decimal.Decimal{Value: 100}, decimal.Decimal{Value: 1, PowerOfTen: 2}}: {
@@ -70,8 +72,8 @@ var sharedTestCases = map[struct{ x, y decimal.Decimal }]struct {
 	{decimal.Decimal{Sign: true, Value: 2}, decimal.Decimal{Sign: true, Value: math.MaxUint64}}: {
 		add:    decimal.Decimal{Sign: true, Value: (math.MaxUint64/10 + 1), PowerOfTen: 1},
 		sub:    decimal.Decimal{Sign: false, Value: math.MaxUint64 - 2},
-		mult:   decimal.Decimal{Sign: true, Value: (math.MaxUint64 / 10 * 2), PowerOfTen: 1},
-		div:    decimal.Decimal{Sign: true, Value: 1, PowerOfTen: -19},
+		mult:   decimal.Decimal{Sign: true, Value: 3689348814741910323, PowerOfTen: 1},
+		div:    decimal.Decimal{Sign: true, Value: 10842021724855044341, PowerOfTen: -38},
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: true,
 	},
 	{decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 0}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 100}}: {
@@ -89,25 +91,25 @@ var sharedTestCases = map[struct{ x, y decimal.Decimal }]struct {
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: true,
 	},
 	{decimal.Decimal{Sign: true, Value: math.MaxUint64 - 1, PowerOfTen: math.MaxInt64}, decimal.Decimal{Sign: true, Value: 2, PowerOfTen: math.MaxInt64}}: {
-		add:    decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64},
+		add:    decimal.Inf(true),
 		sub:    decimal.Decimal{Sign: true, Value: math.MaxUint64 - 3, PowerOfTen: math.MaxInt64},
-		mult:   decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64},
+		mult:   decimal.Inf(true),
 		div:    decimal.Decimal{Sign: true, Value: (math.MaxUint64 - 1) / 2, PowerOfTen: 0},
 		add_ok: false, sub_ok: true, mult_ok: false, div_ok: true,
 	},
 	{decimal.Decimal{Value: 1234567891234, PowerOfTen: math.MaxInt64}, decimal.Decimal{Value: 123456789, PowerOfTen: -1}}: {
 		add:    decimal.Decimal{Value: 1234567891234, PowerOfTen: math.MaxInt64},
 		sub:    decimal.Decimal{Value: 1234567891234, PowerOfTen: math.MaxInt64},
-		mult:   decimal.Decimal{Sign: true, Value: (1234567891234 * 12345678), PowerOfTen: math.MaxInt64},
-		div:    decimal.Decimal{Sign: true, Value: 100000000099, PowerOfTen: 9223372036854775801},
+		mult:   decimal.Decimal{Sign: true, Value: 15241578765425088763, PowerOfTen: math.MaxInt64},
+		div:    decimal.Decimal{Sign: true, Value: 10000000009995400091, PowerOfTen: math.MaxInt64 - 14},
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: true,
 	},
 	{decimal.Decimal{Value: 1, PowerOfTen: math.MaxInt64 - 1}, decimal.Decimal{Value: 1, PowerOfTen: 2}}: {
 		add:    decimal.Decimal{Value: 1, PowerOfTen: math.MaxInt64 - 1},
 		sub:    decimal.Decimal{Value: 1, PowerOfTen: math.MaxInt64 - 1},
-		mult:   decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64},
+		mult:   decimal.Decimal{Sign: true, Value: 10, PowerOfTen: math.MaxInt64},
 		div:    decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64 - 3},
-		add_ok: true, sub_ok: true, mult_ok: false, div_ok: true,
+		add_ok: true, sub_ok: true, mult_ok: true, div_ok: true,
 	},
 	{decimal.Decimal{Value: 1, PowerOfTen: math.MinInt64 + 1}, decimal.Decimal{Value: 1, PowerOfTen: -2}}: {
 		add:    decimal.Decimal{Value: 1, PowerOfTen: -2},
@@ -119,15 +121,15 @@ var sharedTestCases = map[struct{ x, y decimal.Decimal }]struct {
 	{decimal.Decimal{Value: math.MaxUint64, PowerOfTen: math.MinInt64 + 1}, decimal.Decimal{Value: math.MaxUint64, PowerOfTen: 2}}: {
 		add:    decimal.Decimal{Value: math.MaxUint64, PowerOfTen: 2},
 		sub:    decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: 2},
-		mult:   decimal.Decimal{Sign: true, Value: 3402823667840801649, PowerOfTen: math.MinInt64 + 23},
-		div:    decimal.Decimal{Sign: true, Value: 10, PowerOfTen: math.MinInt64},
-		add_ok: true, sub_ok: true, mult_ok: true, div_ok: true,
+		mult:   decimal.Decimal{Sign: true, Value: 3402823669209384634, PowerOfTen: math.MinInt64 + 23},
+		div:    decimal.Decimal{Sign: true, Value: 0, PowerOfTen: 0},
+		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 	{decimal.Decimal{Value: math.MaxUint64, PowerOfTen: math.MinInt64 + 1}, decimal.Decimal{Value: 2, PowerOfTen: 2}}: {
 		add:    decimal.Decimal{Value: 2, PowerOfTen: 2},
 		sub:    decimal.Decimal{Sign: true, Value: 2, PowerOfTen: 2},
-		mult:   decimal.Decimal{Sign: true, Value: 3689348814741910322, PowerOfTen: math.MinInt64 + 4},
-		div:    decimal.Decimal{Sign: true, Value: 0, PowerOfTen: math.MinInt64},
+		mult:   decimal.Decimal{Sign: true, Value: 3689348814741910323, PowerOfTen: math.MinInt64 + 4},
+		div:    decimal.Decimal{Sign: true, Value: 922337203685477581, PowerOfTen: math.MinInt64},
 		add_ok: true, sub_ok: true, mult_ok: true, div_ok: false,
 	},
 }
@@ -183,6 +185,222 @@ func TestAritmetic(t *testing.T) {
 	}
 }
 
+func TestAritmeticErrors(t *testing.T) {
+	// The value-returning variants should agree with the methods
+	for test, expected := range sharedTestCases {
+		operations := map[string]struct {
+			function func(x, y decimal.Decimal) (decimal.Decimal, error)
+			result   decimal.Decimal
+			ok       bool
+		}{
+			"add":  {decimal.Add, expected.add, expected.add_ok},
+			"sub":  {decimal.Sub, expected.sub, expected.sub_ok},
+			"mult": {decimal.Mult, expected.mult, expected.mult_ok},
+			"div":  {decimal.Div, expected.div, expected.div_ok},
+		}
+		for name, operation := range operations {
+			result, err := operation.function(test.x, test.y)
+			if !result.Equals(operation.result) || (err == nil) != operation.ok {
+				t.Fatalf("%s with %v and %v expected (%v, %v) but got (%v, %v)",
+					name, test.x, test.y, operation.result, operation.ok, result, err)
+			}
+		}
+	}
+	// Make sure the right error is returned
+	testCases := map[struct {
+		x, y string
+		op   byte
+	}]error{
+		{"1", "3", '/'}:       nil,
+		{"1", "0", '/'}:       decimal.ErrDivisionByZero,
+		{"-1", "0", '/'}:      decimal.ErrDivisionByZero,
+		{"0", "0", '/'}:       decimal.ErrIndeterminate,
+		{"0", "-0", '/'}:      decimal.ErrIndeterminate,
+		{"1e-1", "1e-1", '+'}: nil,
+		{"1e9223372036854775807", "1e9223372036854775807", '*'}:                    decimal.ErrOverflow,
+		{"-1e9223372036854775807", "1e9223372036854775807", '*'}:                   decimal.ErrOverflow,
+		{"-1e9223372036854775807", "-1e9223372036854775807", '+'}:                  nil,
+		{"18446744073709551615e9223372036854775807", "1e9223372036854775807", '+'}: decimal.ErrOverflow,
+		{"1e-9223372036854775807", "1e-9223372036854775807", '*'}:                  decimal.ErrUnderflow,
+		{"1e-9223372036854775807", "3", '/'}:                                       decimal.ErrUnderflow,
+		{"1e-9223372036854775806", "4", '/'}:                                       nil,
+		{"1e9223372036854775807", "1e-9223372036854775807", '/'}:                   decimal.ErrOverflow,
+		{"Inf", "-Inf", '+'}:  decimal.ErrIndeterminate,
+		{"Inf", "Inf", '-'}:   decimal.ErrIndeterminate,
+		{"Inf", "0", '*'}:     decimal.ErrIndeterminate,
+		{"Inf", "-Inf", '/'}:  decimal.ErrIndeterminate,
+		{"Inf", "0", '/'}:     nil,
+		{"NaN", "0", '/'}:     nil,
+		{"NaN", "Inf", '*'}:   nil,
+		{"Inf", "1e300", '+'}: nil,
+	}
+	for test, expected := range testCases {
+		x, y := mustParse(t, test.x), mustParse(t, test.y)
+		var err error
+		switch test.op {
+		case '+':
+			_, err = decimal.Add(x, y)
+		case '-':
+			_, err = decimal.Sub(x, y)
+		case '*':
+			_, err = decimal.Mult(x, y)
+		case '/':
+			_, err = decimal.Div(x, y)
+		}
+		if !errors.Is(err, expected) {
+			t.Fatalf("%s %c %s expected error %v, instead got %v", test.x, test.op, test.y, expected, err)
+		}
+	}
+}
+
+func TestQuoRem(t *testing.T) {
+	testCases := map[struct{ x, y string }]struct {
+		q, r, mod string
+		err       error
+	}{
+		{"7.5", "2"}:                      {"3", "1.5", "1.5", nil},
+		{"-7.5", "2"}:                     {"-3", "-1.5", "0.5", nil},
+		{"7.5", "-2"}:                     {"-3", "1.5", "-0.5", nil},
+		{"-7.5", "-2"}:                    {"3", "-1.5", "-1.5", nil},
+		{"1", "0.3"}:                      {"3", "0.1", "0.1", nil},
+		{"5", "5"}:                        {"1", "0", "0", nil},
+		{"0.5", "5"}:                      {"0", "0.5", "0.5", nil},
+		{"-0", "5"}:                       {"0", "0", "0", nil},
+		{"17.000000000000000001", "10"}:   {"1", "7.000000000000000001", "7.000000000000000001", nil},
+		{"1e-30", "7"}:                    {"0", "1e-30", "1e-30", nil},
+		{"-1e-30", "7"}:                   {"0", "-1e-30", "7", nil},
+		{"12e100", "5e99"}:                {"24", "0", "0", nil},
+		{"18446744073709551615", "1"}:     {"18446744073709551615", "0", "0", nil},
+		{"1e38", "1e19"}:                  {"10000000000000000000", "0", "0", nil},
+		{"3", "Inf"}:                      {"0", "3", "3", nil},
+		{"-3", "Inf"}:                     {"0", "-3", "Inf", nil},
+		{"18446744073709551620", "1"}:     {"Inf", "NaN", "NaN", decimal.ErrOverflow},
+		{"123456789e20", "1e2"}:           {"Inf", "NaN", "NaN", decimal.ErrOverflow},
+		{"-10", "1e-30"}:                  {"-Inf", "NaN", "NaN", decimal.ErrOverflow},
+		{"1e9223372036854775807", "1e-1"}: {"Inf", "NaN", "NaN", decimal.ErrOverflow},
+		{"1", "0"}:                        {"Inf", "NaN", "NaN", decimal.ErrDivisionByZero},
+		{"-1", "0"}:                       {"-Inf", "NaN", "NaN", decimal.ErrDivisionByZero},
+		{"0", "0"}:                        {"NaN", "NaN", "NaN", decimal.ErrIndeterminate},
+		{"Inf", "2"}:                      {"NaN", "NaN", "NaN", decimal.ErrIndeterminate},
+		{"NaN", "2"}:                      {"NaN", "NaN", "NaN", nil},
+	}
+	for test, expected := range testCases {
+		x, y := mustParse(t, test.x), mustParse(t, test.y)
+		q, r, err := decimal.QuoRem(x, y)
+		if !q.Equals(mustParse(t, expected.q)) || !r.Equals(mustParse(t, expected.r)) || !errors.Is(err, expected.err) {
+			t.Fatalf("QuoRem(%s, %s) expected (%s, %s, %v), instead got (%v, %v, %v)",
+				test.x, test.y, expected.q, expected.r, expected.err, q, r, err)
+		}
+		if rem, err := decimal.Rem(x, y); !rem.Equals(r) || !errors.Is(err, expected.err) {
+			t.Fatalf("Rem(%s, %s) expected (%s, %v), instead got (%v, %v)", test.x, test.y, expected.r, expected.err, rem, err)
+		}
+		if mod, err := decimal.Mod(x, y); !mod.Equals(mustParse(t, expected.mod)) || !errors.Is(err, expected.err) {
+			t.Fatalf("Mod(%s, %s) expected (%s, %v), instead got (%v, %v)", test.x, test.y, expected.mod, expected.err, mod, err)
+		}
+	}
+}
+
+func TestAritmeticRounding(t *testing.T) {
+	// Cases where digits of the operands would be lost without a wider intermediate result
+	testCases := map[struct {
+		x, y string
+		op   byte
+	}]string{
+		{"12345678901234567890", "0.5", '+'}:                   "12345678901234567890",
+		{"12345678901234567890", "0.6", '+'}:                   "12345678901234567891",
+		{"12345678901234567891", "0.5", '+'}:                   "12345678901234567892",
+		{"12345678901234567890", "0.4", '-'}:                   "12345678901234567890",
+		{"12345678901234567890", "0.6", '-'}:                   "12345678901234567889",
+		{"1e19", "0.4", '-'}:                                   "1e19",
+		{"1e40", "1", '-'}:                                     "1e40",
+		{"1e40", "1", '+'}:                                     "1e40",
+		{"-1e40", "1", '+'}:                                    "-1e40",
+		{"1e40", "1e-40", '-'}:                                 "1e40",
+		{"1", "1e-40", '-'}:                                    "1",
+		{"9999999999999999999", "0.5", '+'}:                    "10000000000000000000",
+		{"0.1", "0.2", '+'}:                                    "0.3",
+		{"12345.6789", "987.654321", '-'}:                      "11358.024579",
+		{"1.5", "1.50", '-'}:                                   "0",
+		{"18446744073709551615", "1", '+'}:                     "1844674407370955162e1",
+		{"1e19", "0.1", '+'}:                                   "1e19",
+		{"9999999999999999999", "9999999999999999999", '*'}:    "9999999999999999998e19",
+		{"12345678901234567890", "3", '*'}:                     "3703703670370370367e1",
+		{"1234567890.123456789", "1234567890.123456789", '*'}:  "1524157875323883675.0e0",
+		{"0.0000000001", "0.0000000001", '*'}:                  "1e-20",
+		{"-18446744073709551615", "18446744073709551615", '*'}: "-3402823669209384634e20",
+	}
+	for test, result := range testCases {
+		x, errX := decimal.ParseString(test.x)
+		y, errY := decimal.ParseString(test.y)
+		expected, errExpected := decimal.ParseString(result)
+		if errX != nil || errY != nil || errExpected != nil {
+			t.Fatalf("Failed to setup test: %v, %v, %v", errX, errY, errExpected)
+		}
+		actual := decimal.Decimal{}
+		ok := false
+		switch test.op {
+		case '+':
+			ok = actual.Add(x, y)
+		case '-':
+			ok = actual.Sub(x, y)
+		case '*':
+			ok = actual.Mult(x, y)
+		}
+		if !ok || !actual.Equals(expected) {
+			t.Fatalf("%s %c %s expected (%v, true), instead got (%v, %v)", test.x, test.op, test.y, expected, actual, ok)
+		}
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	testCases := map[struct {
+		x, y      string
+		precision int
+		mode      decimal.RoundingMode
+	}]string{
+		{"1", "3", 0, decimal.RoundHalfEven}:      "0.3333333333333333333",
+		{"1", "3", 25, decimal.RoundHalfEven}:     "0.3333333333333333333",
+		{"2", "3", 0, decimal.RoundHalfEven}:      "0.6666666666666666667",
+		{"1", "7", 0, decimal.RoundHalfEven}:      "0.14285714285714285714",
+		{"1", "3", 5, decimal.RoundHalfEven}:      "0.33333",
+		{"2", "3", 5, decimal.RoundHalfEven}:      "0.66667",
+		{"2", "3", 5, decimal.RoundDown}:          "0.66666",
+		{"-2", "3", 3, decimal.RoundFloor}:        "-0.667",
+		{"-2", "3", 3, decimal.RoundCeiling}:      "-0.666",
+		{"1", "8", 2, decimal.RoundHalfEven}:      "0.12",
+		{"1", "8", 2, decimal.RoundHalfUp}:        "0.13",
+		{"1", "8", 2, decimal.RoundHalfDown}:      "0.12",
+		{"10", "4", 1, decimal.RoundHalfEven}:     "2",
+		{"10", "4", 1, decimal.RoundUp}:           "3",
+		{"999", "1", 2, decimal.RoundHalfEven}:    "1000",
+		{"12345", "0.001", 3, decimal.RoundDown}:  "12300000",
+		{"1.25", "0.5", 0, decimal.RoundHalfEven}: "2.5",
+		{"100", "7", 4, decimal.RoundUp}:          "14.29",
+		{"1", "1e-30", 0, decimal.RoundHalfEven}:  "1e30",
+	}
+	for test, result := range testCases {
+		x, errX := decimal.ParseString(test.x)
+		y, errY := decimal.ParseString(test.y)
+		expected, errExpected := decimal.ParseString(result)
+		if errX != nil || errY != nil || errExpected != nil {
+			t.Fatalf("Failed to setup test: %v, %v, %v", errX, errY, errExpected)
+		}
+		actual := decimal.Decimal{}
+		if !actual.DivRound(x, y, test.precision, test.mode) {
+			t.Fatalf("%s / %s with precision %d and mode %d unexpectedly failed", test.x, test.y, test.precision, test.mode)
+		}
+		if !actual.Equals(expected) {
+			t.Fatalf("%s / %s with precision %d and mode %d expected %v, instead got %v",
+				test.x, test.y, test.precision, test.mode, expected, actual)
+		}
+	}
+	// Make sure we handle nil
+	var nilDecimal *decimal.Decimal = nil
+	if nilDecimal.DivRound(decimal.DecimalFromInt(1), decimal.DecimalFromInt(3), 0, decimal.RoundHalfEven) {
+		t.Fatal("Expect DivRound to return false if run on a nil decimal")
+	}
+}
+
 func BenchmarkAritmetic(b *testing.B) {
 	dec := decimal.Decimal{}
 	cases := []struct{ x, y decimal.Decimal }{}
@@ -212,94 +430,116 @@ func BenchmarkAritmetic(b *testing.B) {
 	})
 }
 
-// NOTE: Since subtraction uses the same base function as addition, this effectively tests both
-func FuzzAdd(f *testing.F) {
-	// Setup some helpers
-
-	// Returns true if x + y will overflow
-	overflow_sum := func(x, y uint64) bool {
-		return math.MaxUint64-x < y
-	}
-
-	// Returns true if x - y will overflow
-	overflow_subtraction := func(x, y uint64) bool {
-		return x < y
+// Benchmark the operations on numbers that commonly show up when dealing with money,
+// where the results tend to fit in a uint64 without rounding
+func BenchmarkAritmeticCommon(b *testing.B) {
+	dec := decimal.Decimal{}
+	cases := []struct{ x, y decimal.Decimal }{
+		{decimal.Decimal{Sign: true, Value: 1999, PowerOfTen: -2}, decimal.Decimal{Sign: true, Value: 3}},
+		{decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, decimal.Decimal{Sign: false, Value: 875, PowerOfTen: -3}},
+		{decimal.Decimal{Sign: false, Value: 4250, PowerOfTen: -2}, decimal.Decimal{Sign: true, Value: 1075, PowerOfTen: -4}},
+		{decimal.Decimal{Sign: true, Value: 100000000, PowerOfTen: -2}, decimal.Decimal{Sign: true, Value: 7, PowerOfTen: -2}},
+		{decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -4}, decimal.Decimal{Sign: false, Value: 987654321, PowerOfTen: -6}},
 	}
+	casesLen := len(cases)
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dec.Add(cases[i%casesLen].x, cases[i%casesLen].y)
+		}
+	})
+	b.Run("Sub", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dec.Sub(cases[i%casesLen].x, cases[i%casesLen].y)
+		}
+	})
+	b.Run("Mult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dec.Mult(cases[i%casesLen].x, cases[i%casesLen].y)
+		}
+	})
+	b.Run("Div", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dec.Div(cases[i%casesLen].x, cases[i%casesLen].y)
+		}
+	})
+}
 
-	// Add to seed corpus
-	testCases := []struct {
-		first_sign  bool
-		first       uint64
-		second_sign bool
-		second      uint64
+func FuzzAritmetic(f *testing.F) {
+	seeds := []struct {
+		x, y decimal.Decimal
 	}{
-		{true, 0, true, 0},
-		{false, 0, true, 0},
-		{true, 0, false, 0},
-		{false, 0, false, 0},
-		{true, 1, true, 1},
-		{false, 1, true, 1},
-		{true, 1, false, 1},
-		{false, 1, false, 1},
-		{true, 123, true, 234},
-		{false, 123, true, 234},
-		{true, 123, false, 234},
-		{false, 123, false, 234},
+		{decimal.Decimal{}, decimal.Decimal{}},
+		{decimal.Decimal{Sign: true, Value: 1}, decimal.Decimal{Sign: false, Value: 1}},
+		{decimal.Decimal{Sign: true, Value: 123, PowerOfTen: -2}, decimal.Decimal{Sign: true, Value: 234, PowerOfTen: 1}},
+		{decimal.Decimal{Sign: false, Value: 123456789, PowerOfTen: -4}, decimal.Decimal{Sign: true, Value: 987654321, PowerOfTen: -6}},
+		{decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: 10}, decimal.Decimal{Sign: false, Value: 3, PowerOfTen: -30}},
+		{decimal.Decimal{Sign: true, Value: math.MaxUint64}, decimal.Decimal{Sign: true, Value: math.MaxUint64 - 1, PowerOfTen: -1}},
 	}
-	for _, seed := range testCases {
-		f.Add(seed.first_sign, seed.first, seed.second_sign, seed.second)
+	for _, seed := range seeds {
+		f.Add(seed.x.Sign, seed.x.Value, int8(seed.x.PowerOfTen), seed.y.Sign, seed.y.Value, int8(seed.y.PowerOfTen))
 	}
-
-	// Fuzz
-	f.Fuzz(func(t *testing.T, first_sign bool, first uint64, second_sign bool, second uint64) {
-		// Prepare actual value
-		first_decimal := decimal.Decimal{
-			Sign:       first_sign,
-			Value:      first,
-			PowerOfTen: 0,
+	f.Fuzz(func(t *testing.T, xSign bool, xValue uint64, xPower int8, ySign bool, yValue uint64, yPower int8) {
+		x := decimal.Decimal{Sign: xSign, Value: xValue, PowerOfTen: int64(xPower)}
+		y := decimal.Decimal{Sign: ySign, Value: yValue, PowerOfTen: int64(yPower)}
+		// The result is correctly rounded if it's within half a unit of the last digit from the exact result
+		check := func(op string, result decimal.Decimal, ok bool, expected *big.Rat) {
+			if !ok {
+				t.Fatalf("%v %s %v failed", x, op, y)
+			}
+			result.Expand()
+			if result.IsZero() {
+				result.PowerOfTen = math.MinInt16
+			}
+			halfUnit := toRat(decimal.Decimal{Sign: true, Value: 5, PowerOfTen: result.PowerOfTen - 1})
+			if difference := new(big.Rat).Sub(toRat(result), expected); difference.Abs(difference).Cmp(halfUnit) > 0 {
+				t.Fatalf("%v %s %v returned %v, expected %s", x, op, y, result, expected.FloatString(50))
+			}
 		}
-		second_decimal := decimal.Decimal{
-			Sign:       second_sign,
-			Value:      second,
-			PowerOfTen: 0,
+		result := decimal.Decimal{}
+		ok := result.Add(x, y)
+		check("+", result, ok, new(big.Rat).Add(toRat(x), toRat(y)))
+		ok = result.Sub(x, y)
+		check("-", result, ok, new(big.Rat).Sub(toRat(x), toRat(y)))
+		ok = result.Mult(x, y)
+		check("*", result, ok, new(big.Rat).Mul(toRat(x), toRat(y)))
+		if !y.IsZero() {
+			ok = result.Div(x, y)
+			check("/", result, ok, new(big.Rat).Quo(toRat(x), toRat(y)))
 		}
-		actual := decimal.Decimal{}
-		actual.Add(first_decimal, second_decimal)
-		// Prepare expected value
-		expected_sign := first_sign
-		expected := first + second
-		if first_sign != second_sign {
-			// Yuck, nested IFs!
-			expected_sign = true
-			if first_sign {
-				// first - second
-				expected = first - second
-				if overflow_subtraction(first, second) {
-					expected_sign = false
-					expected = second - first
-				}
-			} else {
-				// second - first
-				expected = second - first
-				if overflow_subtraction(second, first) {
-					expected_sign = false
-					expected = first - second
-				}
+	})
+}
+
+func FuzzQuoRem(f *testing.F) {
+	f.Add(true, uint64(75), int8(-1), true, uint64(2), int8(0))
+	f.Add(false, uint64(75), int8(-1), true, uint64(2), int8(0))
+	f.Add(true, uint64(1), int8(-30), false, uint64(7), int8(0))
+	f.Add(true, uint64(math.MaxUint64), int8(19), true, uint64(3), int8(-19))
+	f.Fuzz(func(t *testing.T, xSign bool, xValue uint64, xPower int8, ySign bool, yValue uint64, yPower int8) {
+		x := decimal.Decimal{Sign: xSign, Value: xValue, PowerOfTen: int64(xPower)}
+		y := decimal.Decimal{Sign: ySign, Value: yValue, PowerOfTen: int64(yPower)}
+		q, r, err := decimal.QuoRem(x, y)
+		if y.IsZero() {
+			if (x.IsZero() && !q.IsNaN()) || (!x.IsZero() && !q.IsInf()) || !r.IsNaN() || err == nil {
+				t.Fatalf("QuoRem(%v, %v) expected an infinity or NaN, instead got %v and %v", x, y, q, r)
+			}
+			return
+		}
+		// The exact integer quotient
+		ratio := new(big.Rat).Quo(toRat(x), toRat(y))
+		expectedQ := new(big.Int).Quo(ratio.Num(), ratio.Denom())
+		if magnitude := new(big.Int).Abs(expectedQ); !magnitude.IsUint64() {
+			if !errors.Is(err, decimal.ErrOverflow) {
+				t.Fatalf("QuoRem(%v, %v) expected an overflow, instead got %v and %v", x, y, q, r)
 			}
-		} else if overflow_sum(first, second) {
-			// The logic in this fuzz is already pretty complex, so we'll actually skip this.
-			// What we're testing here is that for trivial operations we can produce valid results
-			// and for all operations we don't panic.
-			t.SkipNow()
+			return
 		}
-		expected_decimal := decimal.Decimal{
-			Sign:       expected_sign,
-			Value:      expected,
-			PowerOfTen: 0,
+		if err != nil || q.PowerOfTen != 0 || toRat(q).Cmp(new(big.Rat).SetInt(expectedQ)) != 0 {
+			t.Fatalf("QuoRem(%v, %v) expected a quotient of %v, instead got (%v, %v)", x, y, expectedQ, q, err)
 		}
-		// Compare actual to expected
-		if !expected_decimal.Equals(actual) {
-			t.Fatalf("For %v + %v\ngot %v,\nbut expected %v", first, second, actual, expected)
+		// x = q * y + r
+		expectedR := new(big.Rat).Sub(toRat(x), new(big.Rat).Mul(new(big.Rat).SetInt(expectedQ), toRat(y)))
+		if toRat(r).Cmp(expectedR) != 0 || (!r.IsZero() && r.Sign != x.Sign) {
+			t.Fatalf("QuoRem(%v, %v) expected a remainder of %v, instead got %v", x, y, expectedR, r)
 		}
 	})
 }
@@ -0,0 +1,110 @@
+package decimal
+
+import "math/big"
+
+// The largest power of ten (in absolute value) of a number converted to a math/big type.
+// Larger powers would need megabytes of memory, or more than any computer has
+const maxBigPower = 1 << 20
+
+// Returns a decimal based on coef * 10^exp, a nil coef is treated as zero.
+// If the number can't be represented exactly, it's rounded with RoundHalfEven and exact is false
+func NewFromBigInt(coef *big.Int, exp int64) (d Decimal, exact bool) {
+	if coef == nil || coef.Sign() == 0 {
+		return Decimal{Sign: true}, true
+	}
+	d, cond := roundBigCoefficient(coef.Sign() > 0, new(big.Int).Abs(coef), false, newWidePower(exp), Context{})
+	return d, cond&ConditionInexact == 0
+}
+
+// Returns a decimal based on r rounded to `precision` significant digits with the given mode,
+// a precision <= 0 keeps as many digits as fit in a Decimal. A nil r is treated as zero.
+// Exact results don't have trailing zeroes, otherwise exact is false
+//
+// Example:
+//
+//	third, exact := decimal.NewFromRat(big.NewRat(1, 3), 5, decimal.RoundHalfEven) // 0.33333, false
+func NewFromRat(r *big.Rat, precision int, mode RoundingMode) (d Decimal, exact bool) {
+	if r == nil || r.Sign() == 0 {
+		return Decimal{Sign: true}, true
+	}
+	ctx := Context{Precision: precision, Rounding: mode}
+	kept := resultDigits(ctx)
+	// Scale the numerator so that the quotient has at least one more digit than needed
+	numerator, denominator := new(big.Int).Abs(r.Num()), r.Denom()
+	shift := int64(kept[len(kept)-1]+2) + int64(bigDigits(denominator)) - int64(bigDigits(numerator))
+	if shift > 0 {
+		numerator.Mul(numerator, bigPowerOfTen(uint64(shift)))
+	} else {
+		shift = 0
+	}
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	d, cond := roundBigCoefficient(r.Sign() > 0, quotient, remainder.Sign() != 0, newWidePower(-shift), ctx)
+	if exact = cond&ConditionInexact == 0; exact {
+		d.Compress()
+	}
+	return d, exact
+}
+
+// Returns the integer part of the number (discarding any fractional part) as a big.Int,
+// and true if the number is an integer.
+// Returns nil and false for NaN, infinities and powers of ten above 1048576
+func (d Decimal) BigInt() (i *big.Int, exact bool) {
+	switch {
+	case !d.IsFinite() || d.PowerOfTen > maxBigPower:
+		return nil, false
+	case d.PowerOfTen >= 0:
+		i = new(big.Int).Mul(new(big.Int).SetUint64(d.Value), bigPowerOfTen(uint64(d.PowerOfTen)))
+	default:
+		i = new(big.Int).SetUint64(d.Trunc().Value)
+	}
+	if !d.Sign {
+		i.Neg(i)
+	}
+	return i, d.IsInteger()
+}
+
+// Returns the exact value of the number as a big.Rat.
+// Returns nil for NaN, infinities and powers of ten above 1048576 or below -1048576
+func (d Decimal) Rat() *big.Rat {
+	if !d.IsFinite() || d.PowerOfTen > maxBigPower || d.PowerOfTen < -maxBigPower {
+		return nil
+	}
+	value := new(big.Int).SetUint64(d.Value)
+	r := new(big.Rat)
+	if d.PowerOfTen >= 0 {
+		r.SetInt(value.Mul(value, bigPowerOfTen(uint64(d.PowerOfTen))))
+	} else {
+		r.SetFrac(value, bigPowerOfTen(uint64(-d.PowerOfTen)))
+	}
+	if !d.Sign {
+		r.Neg(r)
+	}
+	return r
+}
+
+// Returns the number as a big.Float with `prec` bits of mantissa (64 if prec is 0), rounded to the nearest even,
+// and true if it's exact. Infinities return the matching big.Float infinities.
+// Returns nil and false for NaN and powers of ten above 1048576 or below -1048576
+func (d Decimal) BigFloat(prec uint) (f *big.Float, exact bool) {
+	if prec == 0 {
+		prec = 64
+	}
+	switch {
+	case d.IsNaN():
+		return nil, false
+	case d.IsInf():
+		return new(big.Float).SetPrec(prec).SetInf(!d.Sign), true
+	case d.Value == 0:
+		f = new(big.Float).SetPrec(prec)
+		if !d.Sign {
+			f.Neg(f)
+		}
+		return f, true
+	}
+	r := d.Rat()
+	if r == nil {
+		return nil, false
+	}
+	f = new(big.Float).SetPrec(prec).SetRat(r)
+	return f, f.Acc() == big.Exact
+}
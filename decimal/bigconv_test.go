@@ -0,0 +1,184 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestNewFromBigInt(t *testing.T) {
+	testCases := map[struct {
+		coef string
+		exp  int64
+	}]struct {
+		result string
+		exact  bool
+	}{
+		{"12345", -2}:                            {"123.45", true},
+		{"-12345", 3}:                            {"-12345e3", true},
+		{"0", 5}:                                 {"0", true},
+		{"18446744073709551615", 0}:              {"18446744073709551615", true},
+		{"18446744073709551616", 0}:              {"1844674407370955162e1", false},
+		{"123456789012345678901234567890", -5}:   {"1234567890123456789e6", false},
+		{"1000000000000000000000000", -24}:       {"1", true},
+		{"1", math.MaxInt64}:                     {"1e9223372036854775807", true},
+		{"123456789012345678901", math.MaxInt64}: {"Inf", false},
+	}
+	for test, expected := range testCases {
+		coef, _ := new(big.Int).SetString(test.coef, 10)
+		result, exact := decimal.NewFromBigInt(coef, test.exp)
+		if !result.Equals(mustParse(t, expected.result)) || exact != expected.exact {
+			t.Fatalf("NewFromBigInt(%s, %d) expected (%s, %v), instead got (%v, %v)",
+				test.coef, test.exp, expected.result, expected.exact, result, exact)
+		}
+	}
+	if result, exact := decimal.NewFromBigInt(nil, 3); !result.IsZero() || !exact {
+		t.Fatalf("NewFromBigInt(nil, 3) expected (0, true), instead got (%v, %v)", result, exact)
+	}
+}
+
+func TestNewFromRat(t *testing.T) {
+	testCases := map[struct {
+		r         string
+		precision int
+		mode      decimal.RoundingMode
+	}]struct {
+		result string
+		exact  bool
+	}{
+		{"1/3", 5, decimal.RoundHalfEven}:                            {"0.33333", false},
+		{"2/3", 5, decimal.RoundHalfEven}:                            {"0.66667", false},
+		{"2/3", 5, decimal.RoundDown}:                                {"0.66666", false},
+		{"-2/3", 3, decimal.RoundFloor}:                              {"-0.667", false},
+		{"1/3", 0, decimal.RoundHalfEven}:                            {"0.3333333333333333333", false},
+		{"1/4", 0, decimal.RoundHalfEven}:                            {"0.25", true},
+		{"5/2", 1, decimal.RoundHalfEven}:                            {"2", false},
+		{"5/2", 1, decimal.RoundHalfUp}:                              {"3", false},
+		{"123456789012345678901234567890", 0, decimal.RoundHalfEven}: {"1.234567890123456789e29", false},
+		{"1/1024", 0, decimal.RoundHalfEven}:                         {"0.0009765625", true},
+		{"7", 0, decimal.RoundHalfEven}:                              {"7", true},
+		{"0", 3, decimal.RoundHalfEven}:                              {"0", true},
+	}
+	for test, expected := range testCases {
+		r, _ := new(big.Rat).SetString(test.r)
+		result, exact := decimal.NewFromRat(r, test.precision, test.mode)
+		if !result.Equals(mustParse(t, expected.result)) || exact != expected.exact {
+			t.Fatalf("NewFromRat(%s, %d, %d) expected (%s, %v), instead got (%v, %v)",
+				test.r, test.precision, test.mode, expected.result, expected.exact, result, exact)
+		}
+	}
+	// Exact results don't have trailing zeroes
+	if result, _ := decimal.NewFromRat(big.NewRat(1, 4), 0, decimal.RoundHalfEven); result != (decimal.Decimal{Sign: true, Value: 25, PowerOfTen: -2}) {
+		t.Fatalf("Expected 1/4 to be exactly 0.25, instead got %v", result)
+	}
+}
+
+func TestBigConversions(t *testing.T) {
+	testCases := map[string]struct {
+		bigInt      string
+		bigIntExact bool
+		rat         string
+		bigFloat    string
+		floatExact  bool
+	}{
+		"123.45":                  {"123", false, "2469/20", "123.45", false},
+		"-123.45":                 {"-123", false, "-2469/20", "-123.45", false},
+		"12e3":                    {"12000", true, "12000", "12000", true},
+		"-0.5":                    {"0", false, "-1/2", "-0.5", true},
+		"18446744073709551615e20": {"1844674407370955161500000000000000000000", true, "1844674407370955161500000000000000000000", "1.8446744073709551615e+39", false},
+		"0":                       {"0", true, "0", "0", true},
+		"1e-9223372036854775807":  {"0", false, "", "", false},
+		"1e9223372036854775807":   {"", false, "", "", false},
+	}
+	for test, expected := range testCases {
+		d := mustParse(t, test)
+		i, exact := d.BigInt()
+		if (i == nil) != (expected.bigInt == "") || (i != nil && i.String() != expected.bigInt) || exact != expected.bigIntExact {
+			t.Fatalf("%s.BigInt() expected (%s, %v), instead got (%v, %v)", test, expected.bigInt, expected.bigIntExact, i, exact)
+		}
+		r := d.Rat()
+		if (r == nil) != (expected.rat == "") || (r != nil && r.RatString() != expected.rat) {
+			t.Fatalf("%s.Rat() expected %s, instead got %v", test, expected.rat, r)
+		}
+		f, exact := d.BigFloat(0)
+		if (f == nil) != (expected.bigFloat == "") || (f != nil && f.Text('g', 20) != expected.bigFloat) || exact != expected.floatExact {
+			t.Fatalf("%s.BigFloat(0) expected (%s, %v), instead got (%v, %v)", test, expected.bigFloat, expected.floatExact, f, exact)
+		}
+	}
+	// Special cases: NaN, infinities and negative zero
+	if i, exact := decimal.NaN().BigInt(); i != nil || exact {
+		t.Fatalf("NaN.BigInt() expected (nil, false), instead got (%v, %v)", i, exact)
+	}
+	if r := decimal.Inf(true).Rat(); r != nil {
+		t.Fatalf("Inf.Rat() expected nil, instead got %v", r)
+	}
+	if f, exact := decimal.Inf(false).BigFloat(53); !f.IsInf() || f.Signbit() != true || !exact {
+		t.Fatalf("-Inf.BigFloat(53) expected (-Inf, true), instead got (%v, %v)", f, exact)
+	}
+	if f, exact := decimal.NaN().BigFloat(53); f != nil || exact {
+		t.Fatalf("NaN.BigFloat(53) expected (nil, false), instead got (%v, %v)", f, exact)
+	}
+	if f, exact := (decimal.Decimal{}).BigFloat(53); f.Sign() != 0 || !f.Signbit() || !exact {
+		t.Fatalf("-0.BigFloat(53) expected (-0, true), instead got (%v, %v)", f, exact)
+	}
+	// Correctly rounded to the given precision
+	if f, exact := mustParse(t, "0.1").BigFloat(53); f.Text('b', 0) != big.NewFloat(0.1).Text('b', 0) || exact {
+		t.Fatalf("0.1.BigFloat(53) expected (%v, false), instead got (%v, %v)", big.NewFloat(0.1), f, exact)
+	}
+}
+
+func BenchmarkBigConversions(b *testing.B) {
+	d := decimal.Decimal{Sign: true, Value: 12345, PowerOfTen: -2}
+	b.Run("Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Rat()
+		}
+	})
+	b.Run("NewFromRat", func(b *testing.B) {
+		r := big.NewRat(1, 3)
+		for i := 0; i < b.N; i++ {
+			decimal.NewFromRat(r, 0, decimal.RoundHalfEven)
+		}
+	})
+}
+
+func FuzzBigConversions(f *testing.F) {
+	f.Add(true, uint64(12345), int16(-2), int64(1), int64(3), uint8(5))
+	f.Add(false, uint64(math.MaxUint64), int16(300), int64(-7), int64(1024), uint8(0))
+	f.Add(true, uint64(1), int16(-400), int64(math.MaxInt64), int64(3), uint8(19))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int16, numerator int64, denominator int64, precision uint8) {
+		// Decimal to math/big and back
+		d := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		exact := toRat(d)
+		if r := d.Rat(); r.Cmp(exact) != 0 {
+			t.Fatalf("%v.Rat() expected %v, instead got %v", d, exact, r)
+		}
+		if back, ok := decimal.NewFromRat(d.Rat(), 0, decimal.RoundHalfEven); !back.Equals(d) || !ok {
+			t.Fatalf("NewFromRat(%v) expected (%v, true), instead got (%v, %v)", exact, d, back, ok)
+		}
+		i, ok := d.BigInt()
+		if truncated := new(big.Int).Quo(exact.Num(), exact.Denom()); i.Cmp(truncated) != 0 || ok != exact.IsInt() {
+			t.Fatalf("%v.BigInt() expected (%v, %v), instead got (%v, %v)", d, truncated, exact.IsInt(), i, ok)
+		}
+		if back, ok := decimal.NewFromBigInt(i, 0); ok && exact.IsInt() && !back.Equals(d) {
+			t.Fatalf("NewFromBigInt(%v, 0) expected %v, instead got %v", i, d, back)
+		}
+		float, ok := d.BigFloat(200)
+		if floatRat, _ := float.Rat(nil); ok != (floatRat.Cmp(exact) == 0) {
+			t.Fatalf("%v.BigFloat(200) returned (%v, %v)", d, float, ok)
+		}
+		// Rationals to decimal
+		if denominator == 0 {
+			return
+		}
+		r := big.NewRat(numerator, denominator)
+		result, ok := decimal.NewFromRat(r, int(precision%25), decimal.RoundHalfEven)
+		difference := new(big.Rat).Sub(toRat(result), r)
+		halfUnit := toRat(decimal.Decimal{Sign: true, Value: 5, PowerOfTen: result.PowerOfTen - 1})
+		if difference.Abs(difference).Cmp(halfUnit) > 0 || ok != (difference.Sign() == 0) {
+			t.Fatalf("NewFromRat(%v, %d) returned (%v, %v)", r, precision%25, result, ok)
+		}
+	})
+}
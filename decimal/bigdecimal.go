@@ -0,0 +1,488 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	// The maximum number of significant digits a BigDecimal operation will produce,
+	// results with more digits are rounded and the operation reports an overflow
+	MaxBigDigits = 1000
+	// How many significant digits BigDecimal.Div keeps
+	BigDivisionPrecision = 50
+)
+
+// A representation of an arbitrary precision decimal number in scientific notation.
+// A nil Value is treated as zero.
+type BigDecimal struct {
+	Sign       bool
+	Value      *big.Int
+	PowerOfTen int64
+}
+
+// Returns a BigDecimal with the same value of a given Decimal.
+// NaN and infinities can't be represented: the result is zero and ok is false
+func BigDecimalFromDecimal(d Decimal) (result BigDecimal, ok bool) {
+	if !d.IsFinite() {
+		return BigDecimal{Sign: d.Sign, Value: new(big.Int)}, false
+	}
+	return d.big(), true
+}
+
+// Returns a BigDecimal with the same value of this finite decimal
+func (d Decimal) big() BigDecimal {
+	return BigDecimal{
+		Sign:       d.Sign,
+		Value:      new(big.Int).SetUint64(d.Value),
+		PowerOfTen: d.PowerOfTen,
+	}
+}
+
+// Returns the Decimal closest to this number.
+// If the number can't be represented exactly, it's rounded with RoundHalfEven and ok is false.
+func (d BigDecimal) Decimal() (result Decimal, ok bool) {
+	if d.IsZero() {
+		return Decimal{Sign: d.Sign}, true
+	}
+	result, cond := roundBigCoefficient(d.Sign, d.Value, false, newWidePower(d.PowerOfTen), Context{})
+	return result, cond&^ConditionRounded == 0
+}
+
+// Parse an arbitrary precision decimal number from a given string, ignoring any unknown characters.
+// Follows the same rules as ParseString, but never loses precision.
+//
+// Examples:
+//  1. "-1!23.45e-23" parses as -12345 * 10 ^ -25
+//  2. "12%" parses as 12 * 10 ^ -2
+//  3. "123456789012345678901234567890.5" parses as 1234567890123456789012345678905 * 10 ^ -1
+func ParseBigString(numberStr string) (BigDecimal, error) {
+	// Collect all the digits
+	digits := strings.Builder{}
+	powerOfTen := int64(0)
+	sign, percentage, exponent := scanNumber(numberStr, func(digit uint8, fraction bool) {
+		if digits.Len() > 0 || digit != 0 {
+			digits.WriteByte('0' + digit)
+		}
+		if fraction {
+			powerOfTen--
+		}
+	})
+	decimal := BigDecimal{Sign: sign, Value: new(big.Int), PowerOfTen: 0}
+	if digits.Len() == 0 {
+		return decimal, nil
+	}
+	decimal.Value.SetString(digits.String(), 10)
+	if percentage {
+		// 1% == 0.01 == 1e-2
+		powerOfTen -= 2
+	}
+	// Power of 10 ('E'/'e')
+	if len(exponent) > 0 {
+		exponentPower, err := parseExponent(exponent)
+		if err != nil {
+			return BigDecimal{}, err
+		}
+		// Merge powers of 10
+		if overflow_int64(powerOfTen, exponentPower) {
+			return BigDecimal{}, ErrorParsingOverflow
+		}
+		powerOfTen += exponentPower
+	}
+	decimal.PowerOfTen = powerOfTen
+	return decimal, nil
+}
+
+// Implementation of the TextUnmarshaler interface using the ParseBigString function
+func (d *BigDecimal) UnmarshalText(text []byte) (err error) {
+	if d == nil {
+		return ErrorNilPointer
+	}
+	*d, err = ParseBigString(string(text))
+	return err
+}
+
+// Formats a number as a string, with the same parameters as Decimal.FormatText
+func (d BigDecimal) FormatText(asDecimal bool, asPercentage bool, accuracyLimit int) string {
+	// Prepare number
+	d.Compress()
+	return formatNumber(d.Sign, d.value().Append(nil, 10), d.PowerOfTen, asDecimal, asPercentage, accuracyLimit)
+}
+
+// Returns true if the number is zero
+func (d BigDecimal) IsZero() bool {
+	return d.Value == nil || d.Value.Sign() == 0
+}
+
+// Returns true if the number is positive
+func (d BigDecimal) IsPositive() bool {
+	return d.Sign && !d.IsZero()
+}
+
+// Returns true if the number is negative
+func (d BigDecimal) IsNegative() bool {
+	return !d.Sign && !d.IsZero()
+}
+
+// Returns a clone of a number, that doesn't share its Value
+func (d BigDecimal) Clone() BigDecimal {
+	return BigDecimal{
+		Sign:       d.Sign,
+		Value:      d.value(),
+		PowerOfTen: d.PowerOfTen,
+	}
+}
+
+// Makes the number value absolute
+func (d *BigDecimal) Abs() {
+	if d == nil {
+		return
+	}
+	d.Sign = true
+}
+
+// Zeroes the number
+func (d *BigDecimal) Zero() {
+	if d == nil {
+		return
+	}
+	d.Sign = true
+	d.Value = new(big.Int)
+	d.PowerOfTen = 0
+}
+
+// Increase the Value of the number to MaxBigDigits digits compensating by adjusting it's power of ten.
+// Will not affect accuracy or precision.
+func (d *BigDecimal) Expand() {
+	if d == nil {
+		return
+	} else if d.IsZero() {
+		d.PowerOfTen = 0
+		return
+	}
+	digits := uint64(MaxBigDigits) - bigDigits(d.Value)
+	if d.PowerOfTen < 0 && uint64(d.PowerOfTen-math.MinInt64) < digits {
+		digits = uint64(d.PowerOfTen - math.MinInt64)
+	}
+	if digits > 0 && digits <= MaxBigDigits {
+		d.Value = new(big.Int).Mul(d.Value, bigPowerOfTen(digits))
+		d.PowerOfTen -= int64(digits)
+	}
+}
+
+// Compress the Value of the number compensating by adjusting it's power of ten.
+// Will not affect accuracy or precision.
+func (d *BigDecimal) Compress() {
+	if d == nil {
+		return
+	} else if d.IsZero() {
+		d.PowerOfTen = 0
+		return
+	}
+	value := d.value()
+	quotient, remainder := new(big.Int), new(big.Int)
+	for d.PowerOfTen < math.MaxInt64 {
+		quotient.QuoRem(value, bigTen, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		value, quotient = quotient, value
+		d.PowerOfTen++
+	}
+	d.Value = value
+}
+
+// Returns true if the two decimals are equal
+func (d BigDecimal) Equals(x BigDecimal) bool {
+	d.Compress()
+	x.Compress()
+	return (d.IsZero() && x.IsZero()) ||
+		(d.Sign == x.Sign && d.Value.Cmp(x.Value) == 0 && d.PowerOfTen == x.PowerOfTen)
+}
+
+// The conditions raised by an operation that has no result to store
+const failureConditions = ConditionOverflow | ConditionDivisionByZero | ConditionInvalidOperation
+
+// Stores the result of an operation in this decimal, unless the operation overflowed,
+// divided by zero or was invalid: in that case the decimal is left unchanged.
+// Returns the raised conditions
+func (d *BigDecimal) store(result BigDecimal, cond Condition) Condition {
+	if cond&failureConditions == 0 {
+		*d = result
+	}
+	return cond
+}
+
+// Perform the addition x + y and store the result in this decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the result can't be represented exactly, will return false
+// If the operation overflows, the decimal is left unchanged and will return false
+func (d *BigDecimal) Add(x, y BigDecimal) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(addBig(x, y)) == 0
+}
+
+// Perform the subtraction x - y and store the result in this decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the result can't be represented exactly, will return false
+func (d *BigDecimal) Sub(x, y BigDecimal) (ok bool) {
+	y.Sign = !y.Sign
+	return d.Add(x, y)
+}
+
+// Perform the multiplication x * y and store the result in this decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the result can't be represented exactly, will return false
+// If the operation overflows, the decimal is left unchanged and will return false
+func (d *BigDecimal) Mult(x, y BigDecimal) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(roundBig(x.Sign == y.Sign, new(big.Int).Mul(x.value(), y.value()), false,
+		newWidePower(x.PowerOfTen).add(y.PowerOfTen), MaxBigDigits, RoundHalfEven)) == 0
+}
+
+// Perform the division x / y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to BigDivisionPrecision significant digits.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows or a divide-by-zero error is encountered, the decimal is left unchanged and will return false
+func (d *BigDecimal) Div(x, y BigDecimal) (ok bool) {
+	return d.DivRound(x, y, BigDivisionPrecision, RoundHalfEven)
+}
+
+// Perform the division x / y and store the result in this decimal, rounded with the given mode
+// to `precision` significant digits. A precision <= 0 (or above MaxBigDigits) means MaxBigDigits.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows or a divide-by-zero error is encountered, the decimal is left unchanged and will return false
+func (d *BigDecimal) DivRound(x, y BigDecimal, precision int, mode RoundingMode) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(divBig(x, y, precision, mode))&^ConditionInexact == 0
+}
+
+// Performs the addition x + y, rounding to MaxBigDigits if needed
+func addBig(x, y BigDecimal) (BigDecimal, Condition) {
+	// Special case: zero
+	if x.IsZero() && y.IsZero() {
+		return BigDecimal{Sign: x.Sign || y.Sign, Value: new(big.Int)}, 0
+	} else if x.IsZero() {
+		return roundBig(y.Sign, y.value(), false, newWidePower(y.PowerOfTen), MaxBigDigits, RoundHalfEven)
+	} else if y.IsZero() {
+		return roundBig(x.Sign, x.value(), false, newWidePower(x.PowerOfTen), MaxBigDigits, RoundHalfEven)
+	}
+	// Make sure both numbers fit in MaxBigDigits
+	var cond Condition
+	for _, n := range []*BigDecimal{&x, &y} {
+		if bigDigits(n.Value) > MaxBigDigits {
+			var roundCond Condition
+			*n, roundCond = roundBig(n.Sign, n.value(), false, newWidePower(n.PowerOfTen), MaxBigDigits, RoundHalfEven)
+			cond |= roundCond
+		}
+	}
+	// Make sure x is the number with the higher power of ten
+	if x.PowerOfTen < y.PowerOfTen {
+		x, y = y, x
+	}
+	// Bring x to the same power of ten as y, as long as it fits in MaxBigDigits + 2 digits.
+	// Note: the subtraction can't overflow a uint64 since x.PowerOfTen >= y.PowerOfTen
+	delta := uint64(x.PowerOfTen) - uint64(y.PowerOfTen)
+	shift := MaxBigDigits + 2 - bigDigits(x.Value)
+	if delta < shift {
+		shift = delta
+	}
+	high := new(big.Int).Mul(x.Value, bigPowerOfTen(shift))
+	// If x is too large, discard the digits of y that would not matter anyway remembering if any was non-zero.
+	low, sticky := y.Value, false
+	if shift < delta {
+		if delta-shift > bigDigits(low) {
+			low, sticky = new(big.Int), true
+		} else {
+			remainder := new(big.Int)
+			low, remainder = new(big.Int).QuoRem(low, bigPowerOfTen(delta-shift), remainder)
+			sticky = remainder.Sign() != 0
+		}
+	}
+	power := newWidePower(x.PowerOfTen).sub(int64(shift))
+	// Perform operation
+	var result BigDecimal
+	var roundCond Condition
+	if x.Sign == y.Sign {
+		result, roundCond = roundBig(x.Sign, high.Add(high, low), sticky, power, MaxBigDigits, RoundHalfEven)
+	} else if high.Cmp(low) < 0 {
+		// Note: low can only be larger if no digit was discarded
+		result, roundCond = roundBig(y.Sign, high.Sub(low, high), false, power, MaxBigDigits, RoundHalfEven)
+	} else {
+		high.Sub(high, low)
+		if sticky {
+			// x - (y + ε) = (x - y - 1) + (1 - ε)
+			high.Sub(high, bigOne)
+		} else if high.Sign() == 0 {
+			return BigDecimal{Sign: true, Value: high}, cond
+		}
+		result, roundCond = roundBig(x.Sign, high, sticky, power, MaxBigDigits, RoundHalfEven)
+	}
+	return result, cond | roundCond
+}
+
+// Performs the long division x / y, rounded with the given mode to `precision` significant digits.
+// On a division by zero the result is a zero that must not be used, like on an overflow in roundBig
+func divBig(x, y BigDecimal, precision int, mode RoundingMode) (BigDecimal, Condition) {
+	sign := x.Sign == y.Sign
+	if precision <= 0 || precision > MaxBigDigits {
+		precision = MaxBigDigits
+	}
+	// Special case: divide by zero
+	if y.IsZero() {
+		if x.IsZero() {
+			// 0 / 0 = ?
+			return BigDecimal{Sign: sign, Value: new(big.Int)}, ConditionInvalidOperation
+		}
+		// 1 / 0 = infinity
+		return BigDecimal{Sign: sign, Value: new(big.Int)}, ConditionDivisionByZero
+	}
+	// Special case: divide zero
+	if x.IsZero() {
+		// 0 / 1 = 0
+		return BigDecimal{Sign: sign, Value: new(big.Int)}, 0
+	}
+	// Scale x so that the quotient has at least one more digit than needed
+	shift := uint64(0)
+	if xDigits, yDigits := bigDigits(x.Value), bigDigits(y.Value); xDigits < uint64(precision)+1+yDigits {
+		shift = uint64(precision) + 1 + yDigits - xDigits
+	}
+	quotient, remainder := new(big.Int), new(big.Int)
+	quotient.QuoRem(new(big.Int).Mul(x.Value, bigPowerOfTen(shift)), y.Value, remainder)
+	power := newWidePower(x.PowerOfTen).sub(y.PowerOfTen).sub(int64(shift))
+	return roundBig(sign, quotient, remainder.Sign() != 0, power, uint64(precision), mode)
+}
+
+// Rounds sign * coefficient * 10^power to a BigDecimal with at most `precision` significant digits
+// using the given rounding mode. The coefficient might be modified.
+// If sticky is true the coefficient is treated as slightly larger than its value, this is
+// only allowed if the coefficient has more digits than the ones that can be kept.
+// On overflow the result is a zero with ConditionOverflow that must not be stored, see BigDecimal.store
+func roundBig(sign bool, coefficient *big.Int, sticky bool, power widePower, precision uint64, mode RoundingMode) (BigDecimal, Condition) {
+	// Special case: zero
+	if coefficient.Sign() == 0 && !sticky {
+		return BigDecimal{Sign: sign, Value: coefficient}, 0
+	}
+	// Find how many digits need to be discarded
+	drop := uint64(0)
+	digits := bigDigits(coefficient)
+	if digits > precision {
+		drop = digits - precision
+	}
+	// The power of ten can't go below math.MinInt64 so we might need to discard even more digits
+	_, under, _ := power.clamp()
+	if drop < under {
+		drop = under
+	}
+	quotient, remainder := coefficient, new(big.Int)
+	half := -1
+	if drop > digits {
+		// Everything is discarded and it's less than half
+		quotient, remainder = new(big.Int), coefficient
+	} else if drop > 0 {
+		divisor := bigPowerOfTen(drop)
+		quotient.QuoRem(coefficient, divisor, remainder)
+		half = new(big.Int).Lsh(remainder, 1).Cmp(divisor)
+		if half == 0 && sticky {
+			half = 1
+		}
+	}
+	inexact := remainder.Sign() != 0 || sticky
+	if mode.increment(sign, quotient.Bit(0) == 1, half, inexact) {
+		quotient.Add(quotient, bigOne)
+		if bigDigits(quotient) > precision {
+			// We went from 99..9 to 100..0
+			quotient.Quo(quotient, bigTen)
+			drop++
+		}
+	}
+	// Prepare the result
+	result := BigDecimal{Sign: sign, Value: quotient}
+	var cond Condition
+	if inexact {
+		cond |= ConditionInexact
+		if under > 0 {
+			cond |= ConditionUnderflow
+		}
+	}
+	if quotient.Sign() == 0 {
+		return result, cond
+	}
+	if under > 0 {
+		// Note: drop >= under so this can't overflow
+		result.PowerOfTen = math.MinInt64 + int64(drop-under)
+		return result, cond
+	}
+	var over bool
+	result.PowerOfTen, _, over = power.add(int64(drop)).clamp()
+	if over {
+		return BigDecimal{Sign: sign, Value: new(big.Int)}, cond | ConditionInexact | ConditionOverflow
+	}
+	return result, cond
+}
+
+// Rounds sign * coefficient * 10^power to a Decimal according to the given context, like roundCoefficient.
+// If sticky is true the coefficient is treated as slightly larger than its value.
+func roundBigCoefficient(sign bool, coefficient *big.Int, sticky bool, power widePower, ctx Context) (Decimal, Condition) {
+	// Bring the value to at most 38 digits so it fits in a uint128,
+	// keeping track of any non-zero digit discarded
+	if digits := bigDigits(coefficient); digits > uint64(len(powersOfTen128))-1 {
+		drop := digits - uint64(len(powersOfTen128)) + 1
+		remainder := new(big.Int)
+		coefficient, remainder = new(big.Int).QuoRem(coefficient, bigPowerOfTen(drop), remainder)
+		sticky = sticky || remainder.Sign() != 0
+		power = power.add(int64(drop))
+	}
+	value := uint128{
+		hi: new(big.Int).Rsh(coefficient, 64).Uint64(),
+		lo: new(big.Int).And(coefficient, bigMaxUint64).Uint64(),
+	}
+	return roundCoefficient(sign, value, sticky, power, ctx)
+}
+
+// Returns a copy of the Value of this number, or zero if nil
+func (d BigDecimal) value() *big.Int {
+	if d.Value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.Value)
+}
+
+var (
+	bigOne       = big.NewInt(1)
+	bigTen       = big.NewInt(10)
+	bigMaxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+// Returns 10^n as a big.Int
+func bigPowerOfTen(n uint64) *big.Int {
+	if n < uint64(len(powersOfTen)) {
+		return new(big.Int).SetUint64(powersOfTen[n])
+	}
+	return new(big.Int).Exp(bigTen, new(big.Int).SetUint64(n), nil)
+}
+
+// Returns the number of decimal digits of the absolute value of v (0 for zero)
+func bigDigits(v *big.Int) uint64 {
+	if v == nil || v.Sign() == 0 {
+		return 0
+	}
+	// Start from an approximation of log10(2^length) that never exceeds the actual number of digits
+	digits := uint64(v.BitLen()) * 1233 >> 12
+	for v.CmpAbs(bigPowerOfTen(digits)) >= 0 {
+		digits++
+	}
+	return digits
+}
@@ -0,0 +1,268 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+// Parses a BigDecimal or fails the test
+func mustParseBig(t *testing.T, numberStr string) decimal.BigDecimal {
+	t.Helper()
+	number, err := decimal.ParseBigString(numberStr)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	return number
+}
+
+func TestParseBigString(t *testing.T) {
+	testCases := map[string]struct {
+		sign       bool
+		value      string
+		powerOfTen int64
+		hasError   bool
+	}{
+		"":                                 {true, "0", 0, false},
+		"0":                                {true, "0", 0, false},
+		".e":                               {true, "0", 0, false},
+		"0000000000000000000001":           {true, "1", 0, false},
+		"-1!23.45e-23":                     {false, "12345", -25, false},
+		"-1!23.45e-23%":                    {false, "12345", -27, false},
+		"12%":                              {true, "12", -2, false},
+		"90000000000000000000":             {true, "90000000000000000000", 0, false},
+		"123456789012345678901234567890.5": {true, "1234567890123456789012345678905", -1, false},
+		"-0.000000000000000001234567890123456789": {false, "1234567890123456789", -36, false},
+		"1e+92233720368547758070":                 {true, "0", 0, true},
+		"1.5e9223372036854775807":                 {true, "15", 9223372036854775806, false},
+		"0.1e-9223372036854775808":                {true, "0", 0, true},
+	}
+	for numberStr, expected := range testCases {
+		actual, err := decimal.ParseBigString(numberStr)
+		if (err != nil) != expected.hasError {
+			t.Fatalf("%q returned an unexpected error state: %v", numberStr, err)
+		}
+		if expected.hasError {
+			continue
+		}
+		if actual.Sign != expected.sign || actual.Value.String() != expected.value || actual.PowerOfTen != expected.powerOfTen {
+			t.Fatalf("%q expected (%v, %s, %d), instead got (%v, %v, %d)",
+				numberStr, expected.sign, expected.value, expected.powerOfTen,
+				actual.Sign, actual.Value, actual.PowerOfTen)
+		}
+		// UnmarshalText should behave the same
+		unmarshaled := decimal.BigDecimal{}
+		if err := unmarshaled.UnmarshalText([]byte(numberStr)); err != nil || !unmarshaled.Equals(actual) {
+			t.Fatalf("%q UnmarshalText returned (%v, %v), expected %v", numberStr, unmarshaled, err, actual)
+		}
+	}
+	var nilDecimal *decimal.BigDecimal = nil
+	if err := nilDecimal.UnmarshalText([]byte("")); err != decimal.ErrorNilPointer {
+		t.Fatalf("Unexpected error (or lack there of) from [nil].UnmarshalText: %v", err)
+	}
+}
+
+func TestBigDecimalFormat(t *testing.T) {
+	testCases := map[string]string{
+		"":          "0",
+		"-0.000123": "-0.000123",
+		"1234567890123456789012345.000000000000000001": "1234567890123456789012345.000000000000000001",
+		"1.5e30":   "1500000000000000000000000000000",
+		"1200e-50": "0.000000000000000000000000000000000000000000000012",
+	}
+	for numberStr, expected := range testCases {
+		if actual := mustParseBig(t, numberStr).FormatText(false, false, 0); actual != expected {
+			t.Fatalf("Expected %q for %q, instead got %q", expected, numberStr, actual)
+		}
+	}
+	if actual := mustParseBig(t, "0.12345678901234567890123").FormatText(true, true, 4); actual != "1234e-2%" {
+		t.Fatalf("Expected \"1234e-2%%\", instead got %q", actual)
+	}
+}
+
+func TestBigDecimalAritmetic(t *testing.T) {
+	testCases := map[struct{ x, y string }]struct {
+		add, sub, mult, div string
+		div_ok              bool
+	}{
+		{"0", "0"}: {"0", "0", "0", "0", false},
+		{"1", "0"}: {"1", "1", "0", "0", false},
+		{"0", "3"}: {"3", "-3", "0", "0", true},
+		{"1", "3"}: {"4", "-2", "3", "0.33333333333333333333333333333333333333333333333333", true},
+		{"123456789012345678901234567890.123456789012345678", "987654321098765432109876543210.876543210987654322"}: {
+			add:    "1111111110111111111011111111101",
+			sub:    "-864197532086419753208641975320.753086421975308644",
+			mult:   "121932631137021795226185032733853071173633592437709343086559.686023334843773801472031700234720316",
+			div:    "0.12499999886093750001423828124983608398412093566909",
+			div_ok: true,
+		},
+		{"1e100", "-1"}: {
+			add:    "9999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999",
+			sub:    "10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001",
+			mult:   "-1e100",
+			div:    "-1e100",
+			div_ok: true,
+		},
+		{"-2", "8e-10"}: {"-1.9999999992", "-2.0000000008", "-1.6e-9", "-2.5e9", true},
+	}
+	for test, expected := range testCases {
+		x, y := mustParseBig(t, test.x), mustParseBig(t, test.y)
+		var add, sub, mult, div decimal.BigDecimal
+		if !add.Add(x, y) || !add.Equals(mustParseBig(t, expected.add)) {
+			t.Fatalf("%s + %s expected %s, instead got %s", test.x, test.y, expected.add, add.FormatText(false, false, 0))
+		}
+		if !sub.Sub(x, y) || !sub.Equals(mustParseBig(t, expected.sub)) {
+			t.Fatalf("%s - %s expected %s, instead got %s", test.x, test.y, expected.sub, sub.FormatText(false, false, 0))
+		}
+		if !mult.Mult(x, y) || !mult.Equals(mustParseBig(t, expected.mult)) {
+			t.Fatalf("%s * %s expected %s, instead got %s", test.x, test.y, expected.mult, mult.FormatText(false, false, 0))
+		}
+		if div.Div(x, y) != expected.div_ok || !div.Equals(mustParseBig(t, expected.div)) {
+			t.Fatalf("%s / %s expected (%s, %v), instead got %s", test.x, test.y, expected.div, expected.div_ok, div.FormatText(false, false, 0))
+		}
+		// Inputs should not be modified
+		if !x.Equals(mustParseBig(t, test.x)) || !y.Equals(mustParseBig(t, test.y)) {
+			t.Fatalf("Operations on %s and %s modified their inputs", test.x, test.y)
+		}
+	}
+	// Make sure we handle nil
+	var nilDecimal *decimal.BigDecimal = nil
+	if nilDecimal.Add(decimal.BigDecimal{}, decimal.BigDecimal{}) ||
+		nilDecimal.Sub(decimal.BigDecimal{}, decimal.BigDecimal{}) ||
+		nilDecimal.Mult(decimal.BigDecimal{}, decimal.BigDecimal{}) ||
+		nilDecimal.Div(decimal.BigDecimal{}, decimal.BigDecimal{}) {
+		t.Fatal("Expect aritmetic ops to return false if run on a nil decimal")
+	}
+}
+
+func TestBigDecimalLimits(t *testing.T) {
+	// Results with more than MaxBigDigits digits get rounded
+	huge := mustParseBig(t, strings.Repeat("9", decimal.MaxBigDigits))
+	result := decimal.BigDecimal{}
+	if result.Add(huge, mustParseBig(t, "1")) != true {
+		t.Fatal("Expected adding up to MaxBigDigits+1 digits (a power of ten) to be exact")
+	}
+	if result.Add(huge, mustParseBig(t, "2")) != false || !result.Equals(mustParseBig(t, "1e1000")) {
+		t.Fatalf("Expected adding beyond MaxBigDigits to round, instead got %s", result.FormatText(true, false, 0))
+	}
+	if result.Add(mustParseBig(t, "-2"), mustParseBig(t, "8e-1000000")) != false || !result.Equals(mustParseBig(t, "-2")) {
+		t.Fatalf("Expected adding a tiny number to round, instead got %s", result.FormatText(true, false, 0))
+	}
+	if result.Sub(mustParseBig(t, "1e9223372036854775807"), mustParseBig(t, "1e-9223372036854775807")) != false ||
+		!result.Equals(mustParseBig(t, "1e9223372036854775807")) {
+		t.Fatalf("Expected subtracting a tiny number to round, instead got %s", result.FormatText(true, false, 0))
+	}
+	if result.Mult(mustParseBig(t, "1e9223372036854775807"), mustParseBig(t, "1e9223372036854775807")) != false ||
+		!result.Equals(mustParseBig(t, "1e9223372036854775807")) {
+		t.Fatalf("Expected overflow in multiplication to leave the result unchanged, instead got %s", result.FormatText(true, false, 0))
+	}
+	if result.Div(mustParseBig(t, "1"), mustParseBig(t, "0")) != false || result.Div(mustParseBig(t, "0"), mustParseBig(t, "0")) != false ||
+		!result.Equals(mustParseBig(t, "1e9223372036854775807")) {
+		t.Fatalf("Expected division by zero to leave the result unchanged, instead got %s", result.FormatText(true, false, 0))
+	}
+	if result.Mult(mustParseBig(t, "1e-9223372036854775807"), mustParseBig(t, "0.01")) != false || !result.IsZero() {
+		t.Fatal("Expected underflow in multiplication")
+	}
+	if !result.DivRound(mustParseBig(t, "2"), mustParseBig(t, "3"), 5, decimal.RoundDown) ||
+		!result.Equals(mustParseBig(t, "0.66666")) {
+		t.Fatalf("Expected 2/3 with 5 digits to be 0.66666, instead got %s", result.FormatText(false, false, 0))
+	}
+}
+
+func TestBigDecimalUtils(t *testing.T) {
+	number := mustParseBig(t, "-12300")
+	if !number.IsNegative() || number.IsPositive() || number.IsZero() {
+		t.Fatalf("%v should be negative", number)
+	}
+	clone := number.Clone()
+	clone.Abs()
+	if !clone.IsPositive() || !number.IsNegative() {
+		t.Fatalf("Abs() on a clone should not affect the original: %v, %v", clone, number)
+	}
+	clone.Compress()
+	if clone.Value.Int64() != 123 || clone.PowerOfTen != 2 {
+		t.Fatalf("Expected compress to return 123e2, instead got %v", clone)
+	}
+	clone.Expand()
+	if len(clone.Value.String()) != decimal.MaxBigDigits || clone.PowerOfTen != 2-decimal.MaxBigDigits+3 {
+		t.Fatalf("Expected expand to fill MaxBigDigits digits, instead got %d digits", len(clone.Value.String()))
+	}
+	if !clone.Equals(mustParseBig(t, "12300")) {
+		t.Fatal("Expand and Compress changed the value of the number")
+	}
+	clone.Zero()
+	if !clone.IsZero() || clone.IsPositive() || clone.IsNegative() {
+		t.Fatalf("Failed to Zero() decimal: got %v", clone)
+	}
+	// Zero values should work
+	zero := decimal.BigDecimal{}
+	zero.Compress()
+	zero.Expand()
+	if !zero.IsZero() || !zero.Equals(decimal.BigDecimal{Value: big.NewInt(0)}) {
+		t.Fatal("The zero value of BigDecimal should be zero")
+	}
+	// Can Abs(), Zero(), Compress() and Expand() handle nil without panic?
+	var nilDecimal *decimal.BigDecimal = nil
+	nilDecimal.Abs()
+	nilDecimal.Zero()
+	nilDecimal.Compress()
+	nilDecimal.Expand()
+}
+
+func TestBigDecimalConversion(t *testing.T) {
+	testCases := map[string]struct {
+		decimal decimal.Decimal
+		ok      bool
+	}{
+		"0":                       {decimal.Decimal{Sign: true}, true},
+		"-123.45":                 {decimal.Decimal{Sign: false, Value: 12345, PowerOfTen: -2}, true},
+		"18446744073709551615":    {decimal.Decimal{Sign: true, Value: math.MaxUint64}, true},
+		"18446744073709551615000": {decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: 3}, true},
+		"18446744073709551616":    {decimal.Decimal{Sign: true, Value: 1844674407370955162, PowerOfTen: 1}, false},
+		"1.000000000000000000000000000000000000000000000001":           {decimal.Decimal{Sign: true, Value: 1}, false},
+		"123456789012345678901234567890123456789012345678901234567890": {decimal.Decimal{Sign: true, Value: 12345678901234567890, PowerOfTen: 40}, false},
+		"1e9223372036854775807":                                        {decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, true},
+		"1234e9223372036854775805":                                     {decimal.Decimal{Sign: true, Value: 1234, PowerOfTen: math.MaxInt64 - 2}, true},
+		"12345678901234567890123e9223372036854775805":                  {decimal.Inf(true), false},
+	}
+	for numberStr, expected := range testCases {
+		number := mustParseBig(t, numberStr)
+		actual, ok := number.Decimal()
+		if !actual.Equals(expected.decimal) || ok != expected.ok {
+			t.Fatalf("%q expected to convert to (%v, %v), instead got (%v, %v)", numberStr, expected.decimal, expected.ok, actual, ok)
+		}
+		if back, converted := decimal.BigDecimalFromDecimal(actual); ok && (!converted || !back.Equals(number)) {
+			t.Fatalf("%q didn't convert back to the same BigDecimal", numberStr)
+		}
+	}
+	// NaN and infinities can't be converted
+	for _, special := range []decimal.Decimal{decimal.NaN(), decimal.Inf(true), decimal.Inf(false)} {
+		if result, ok := decimal.BigDecimalFromDecimal(special); ok || !result.IsZero() {
+			t.Fatalf("Expected %v to convert to zero and false, instead got (%v, %v)", special, result, ok)
+		}
+	}
+}
+
+func BenchmarkBigDecimal(b *testing.B) {
+	x, _ := decimal.ParseBigString("123456789012345678901234567890.123456789012345678")
+	y, _ := decimal.ParseBigString("-987654321.876543210987654322")
+	result := decimal.BigDecimal{}
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Add(x, y)
+		}
+	})
+	b.Run("Mult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Mult(x, y)
+		}
+	})
+	b.Run("Div", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Div(x, y)
+		}
+	})
+}
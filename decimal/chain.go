@@ -0,0 +1,117 @@
+package decimal
+
+// The conditions that are reported as errors by chained operations
+const chainFailures = ConditionOverflow | ConditionUnderflow | ConditionDivisionByZero | ConditionInvalidOperation
+
+// The result of a chain of operations (Plus, Minus, Times, DividedBy) on decimals,
+// alongside the first error raised along the way so that it can be checked once at the end.
+// The zero value is a chain holding zero without any error.
+//
+// Example:
+//
+//	total := price.Times(quantity).Minus(discount)
+//	if err := total.Err(); err != nil {
+//		// Handle the error
+//	}
+//	result := total.Decimal()
+type Chain struct {
+	value Decimal
+	// The first error condition raised by the operations of the chain
+	failure Condition
+}
+
+// Returns a chain of operations starting from a given Decimal
+func ChainFromDecimal(d Decimal) Chain {
+	return Chain{value: d}
+}
+
+// Returns the number computed by the chain of operations
+func (c Chain) Decimal() Decimal {
+	return c.value
+}
+
+// Returns the first error raised by the chain of operations, or nil if there was none.
+// The error is one of ErrDivisionByZero, ErrIndeterminate, ErrOverflow or ErrUnderflow
+func (c Chain) Err() error {
+	return conditionError(c.failure)
+}
+
+// Returns a chain starting with the sum d + y, rounded like Add
+func (d Decimal) Plus(y Decimal) Chain {
+	return ChainFromDecimal(d).Plus(y)
+}
+
+// Returns a chain starting with the difference d - y, rounded like Sub
+func (d Decimal) Minus(y Decimal) Chain {
+	return ChainFromDecimal(d).Minus(y)
+}
+
+// Returns a chain starting with the product d * y, rounded like Mult
+func (d Decimal) Times(y Decimal) Chain {
+	return ChainFromDecimal(d).Times(y)
+}
+
+// Returns a chain starting with the quotient d / y, rounded like Div
+func (d Decimal) DividedBy(y Decimal) Chain {
+	return ChainFromDecimal(d).DividedBy(y)
+}
+
+// Returns the number with the opposite sign, NaN stays NaN
+func (d Decimal) Neg() Decimal {
+	if !d.IsNaN() {
+		d.Sign = !d.Sign
+	}
+	return d
+}
+
+// Returns the absolute value of the number without modifying it.
+// Note: it's not called Abs like the method of Chain, since Abs makes a Decimal absolute in place
+func (d Decimal) Absolute() Decimal {
+	d.Abs()
+	return d
+}
+
+// Adds y to the result of the chain, rounding like Add
+func (c Chain) Plus(y Decimal) Chain {
+	result, cond := add(c.value, y, Context{})
+	return c.next(result, cond)
+}
+
+// Subtracts y from the result of the chain, rounding like Sub
+func (c Chain) Minus(y Decimal) Chain {
+	y.Sign = !y.Sign
+	return c.Plus(y)
+}
+
+// Multiplies the result of the chain by y, rounding like Mult
+func (c Chain) Times(y Decimal) Chain {
+	result, cond := mult(c.value, y, Context{})
+	return c.next(result, cond)
+}
+
+// Divides the result of the chain by y, rounding like Div
+func (c Chain) DividedBy(y Decimal) Chain {
+	result, cond := div(c.value, y, Context{})
+	return c.next(result, cond)
+}
+
+// Changes the sign of the result of the chain, NaN stays NaN
+func (c Chain) Neg() Chain {
+	c.value = c.value.Neg()
+	return c
+}
+
+// Takes the absolute value of the result of the chain
+func (c Chain) Abs() Chain {
+	c.value.Abs()
+	return c
+}
+
+// Returns the chain continued with the result of an operation, keeping the first error raised
+func (c Chain) next(result Decimal, cond Condition) Chain {
+	if c.failure == 0 {
+		c.failure = cond & chainFailures
+	}
+	c.value = result
+	return c
+}
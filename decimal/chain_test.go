@@ -0,0 +1,71 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestChain(t *testing.T) {
+	testCases := map[string]struct {
+		result   decimal.Chain
+		expected string
+		err      error
+	}{
+		"(12.5 * 3) - 2.5":  {mustParse(t, "12.5").Times(mustParse(t, "3")).Minus(mustParse(t, "2.5")), "35", nil},
+		"(1 + 2) / 3":       {mustParse(t, "1").Plus(mustParse(t, "2")).DividedBy(mustParse(t, "3")), "1", nil},
+		"-(1 / 3)":          {mustParse(t, "1").DividedBy(mustParse(t, "3")).Neg(), "-0.3333333333333333333", nil},
+		"|-4 - 6|":          {mustParse(t, "-4").Minus(mustParse(t, "6")).Abs(), "10", nil},
+		"(1 / 0) - 1":       {mustParse(t, "1").DividedBy(mustParse(t, "0")).Minus(mustParse(t, "1")), "Inf", decimal.ErrDivisionByZero},
+		"((1 / 0) * 0) + 1": {mustParse(t, "1").DividedBy(mustParse(t, "0")).Times(mustParse(t, "0")).Plus(mustParse(t, "1")), "NaN", decimal.ErrDivisionByZero},
+		"(0 / 0) + 1":       {mustParse(t, "0").DividedBy(mustParse(t, "0")).Plus(mustParse(t, "1")), "NaN", decimal.ErrIndeterminate},
+		"(1e9223372036854775807 * 10) / 1e9223372036854775807": {
+			mustParse(t, "1e9223372036854775807").Times(mustParse(t, "10")).DividedBy(mustParse(t, "1e9223372036854775807")), "10", nil,
+		},
+		"(1e9223372036854775807 * 1e9223372036854775807) / 0": {
+			mustParse(t, "1e9223372036854775807").Times(mustParse(t, "1e9223372036854775807")).DividedBy(mustParse(t, "0")), "Inf", decimal.ErrOverflow,
+		},
+		"(1e-9223372036854775807 / 3) * 3": {
+			mustParse(t, "1e-9223372036854775807").DividedBy(mustParse(t, "3")).Times(mustParse(t, "3")), "0.9e-9223372036854775807", decimal.ErrUnderflow,
+		},
+	}
+	for expression, test := range testCases {
+		if !test.result.Decimal().Equals(mustParse(t, test.expected)) || !errors.Is(test.result.Err(), test.err) {
+			t.Fatalf("%s expected (%s, %v), instead got (%v, %v)", expression, test.expected, test.err,
+				test.result.Decimal(), test.result.Err())
+		}
+	}
+	// The operands are left untouched
+	x, y := mustParse(t, "-1.5"), mustParse(t, "2")
+	x.Plus(y)
+	x.Neg()
+	x.Absolute()
+	if !x.Equals(mustParse(t, "-1.5")) || !y.Equals(mustParse(t, "2")) {
+		t.Fatalf("Expected the operands to be left untouched, instead got %v and %v", x, y)
+	}
+	// NaN has no sign
+	if nan := decimal.NaN().Neg(); !nan.IsNaN() || nan != decimal.NaN() {
+		t.Fatalf("Expected -NaN to be NaN, instead got %v", nan)
+	}
+	// A chain starts without errors, and the zero value holds zero
+	if chain := decimal.ChainFromDecimal(x); chain.Err() != nil || chain.Decimal() != x {
+		t.Fatalf("Expected a chain starting from %v, instead got (%v, %v)", x, chain.Decimal(), chain.Err())
+	}
+	if chain := (decimal.Chain{}).Plus(y); chain.Err() != nil || !chain.Decimal().Equals(y) {
+		t.Fatalf("Expected 0 + %v to be %v, instead got (%v, %v)", y, y, chain.Decimal(), chain.Err())
+	}
+	// The results don't carry the error, so they can be compared with ==
+	if failed := x.DividedBy(decimal.Decimal{Sign: true}); failed.Decimal() != decimal.Inf(false) {
+		t.Fatalf("Expected -1.5 / 0 to be -Inf, instead got %v", failed.Decimal())
+	}
+}
+
+func BenchmarkChain(b *testing.B) {
+	price := decimal.Decimal{Sign: true, Value: 1999, PowerOfTen: -2}
+	quantity := decimal.Decimal{Sign: true, Value: 3}
+	discount := decimal.Decimal{Sign: true, Value: 5, PowerOfTen: -1}
+	for i := 0; i < b.N; i++ {
+		price.Times(quantity).Minus(discount).DividedBy(quantity)
+	}
+}
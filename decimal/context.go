@@ -0,0 +1,231 @@
+package decimal
+
+import "strings"
+
+// Exceptional conditions that can be raised while performing an operation
+type Condition uint8
+
+const (
+	// The result was rounded and some non-zero digits were discarded
+	ConditionInexact Condition = 1 << iota
+	// The result was rounded and some digits (possibly all zeroes) were discarded
+	ConditionRounded
+	// The result is too large to be represented
+	ConditionOverflow
+	// The result is too small to be represented exactly
+	ConditionUnderflow
+	// A non-zero number was divided by zero
+	ConditionDivisionByZero
+	// The operation has no defined result (for example 0/0)
+	ConditionInvalidOperation
+)
+
+// The names of the conditions, in the order of their bits
+var conditionNames = [...]string{"inexact", "rounded", "overflow", "underflow", "division by zero", "invalid operation"}
+
+// Returns a comma separated list of the conditions that are set
+func (c Condition) String() string {
+	names := []string{}
+	for i, name := range conditionNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Implementation of the error interface, returned by a Context when one of its traps is raised
+func (c Condition) Error() string {
+	return "decimal: " + c.String()
+}
+
+// Returns true if target is the error matching one of these conditions (for example ErrOverflow),
+// so that errors.Is can be used on the errors returned by a Context
+func (c Condition) Is(target error) bool {
+	switch target {
+	case ErrIndeterminate:
+		return c&ConditionInvalidOperation != 0
+	case ErrDivisionByZero:
+		return c&ConditionDivisionByZero != 0
+	case ErrOverflow:
+		return c&ConditionOverflow != 0
+	case ErrUnderflow:
+		return c&ConditionUnderflow != 0
+	}
+	return false
+}
+
+// A context in which operations are performed, in the style of the General Decimal Arithmetic specification.
+// The zero value rounds to as many digits as fit in a Decimal with RoundHalfEven, without exponent limits or traps.
+//
+// Example:
+//
+//	ctx := decimal.Context{Precision: 10, Rounding: decimal.RoundHalfUp, ExponentLimits: true, Emin: -99, Emax: 99}
+//	// ... perform all operations with ctx.Add(&d, x, y), ctx.Div(&d, x, y), ...
+//	if ctx.Flags&decimal.ConditionInexact != 0 {
+//		// Some result was rounded
+//	}
+type Context struct {
+	// The maximum number of significant digits of a result, <= 0 means as many as fit in a Decimal
+	Precision int
+	// How results are rounded
+	Rounding RoundingMode
+	// If false, Emin and Emax are ignored and a result can have any int64 PowerOfTen
+	ExponentLimits bool
+	// The smallest and largest adjusted exponent of a (non-zero) result, that is PowerOfTen + digits - 1
+	// (the power of ten of its most significant digit). Results above Emax overflow, while results below
+	// Emin are subnormal: they can't have a PowerOfTen below Emin - (Precision - 1), so they might lose
+	// some digits and underflow. If Emin > Emax, operations leave their result untouched
+	// and raise ConditionInvalidOperation.
+	Emin, Emax int64
+	// The conditions that make an operation return an error
+	Traps Condition
+	// The conditions raised by the operations performed in this context, they are never cleared automatically
+	Flags Condition
+}
+
+// Perform the addition x + y and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Add(d *Decimal, x, y Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := add(x, y, *c)
+	return c.apply(d, result, cond)
+}
+
+// Perform the subtraction x - y and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Sub(d *Decimal, x, y Decimal) error {
+	y.Sign = !y.Sign
+	return c.Add(d, x, y)
+}
+
+// Perform the multiplication x * y and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Mult(d *Decimal, x, y Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := mult(x, y, *c)
+	return c.apply(d, result, cond)
+}
+
+// Perform the division x / y and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Div(d *Decimal, x, y Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := div(x, y, *c)
+	return c.apply(d, result, cond)
+}
+
+// Raise x to the power of n and store the result in d, a negative n computes 1 / x^-n.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) PowInt(d *Decimal, x Decimal, n int64) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := powInt(x, n, *c)
+	return c.apply(d, result, cond)
+}
+
+// Compute the square root of x and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Sqrt(d *Decimal, x Decimal) error {
+	return c.NthRoot(d, x, 2)
+}
+
+// Compute the n-th root of x and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) NthRoot(d *Decimal, x Decimal, n int64) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := nthRoot(x, n, *c)
+	return c.apply(d, result, cond)
+}
+
+// Compute e^x and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Exp(d *Decimal, x Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := exp(x, *c)
+	return c.apply(d, result, cond)
+}
+
+// Compute the natural logarithm of x and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Ln(d *Decimal, x Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := ln(x, *c)
+	return c.apply(d, result, cond)
+}
+
+// Compute the base 10 logarithm of x and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Log10(d *Decimal, x Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := log10(x, *c)
+	return c.apply(d, result, cond)
+}
+
+// Compute the logarithm of x in the given base and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Log(d *Decimal, x, base Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	result, cond := log(x, base, *c)
+	return c.apply(d, result, cond)
+}
+
+// Round x to the context precision and exponent limits and store the result in d.
+// If d is nil, the result is discarded.
+// Returns the raised conditions that are trapped by this context, if any
+func (c *Context) Round(d *Decimal, x Decimal) error {
+	if c.invalid() {
+		return c.apply(nil, Decimal{}, ConditionInvalidOperation)
+	}
+	if !x.IsFinite() {
+		return c.apply(d, x, 0)
+	}
+	result, cond := roundCoefficient(x.Sign, uint128{lo: x.Value}, false, newWidePower(x.PowerOfTen), *c)
+	return c.apply(d, result, cond)
+}
+
+// Records the conditions raised by an operation and stores its result.
+// Returns the conditions that are trapped, if any
+func (c *Context) apply(d *Decimal, result Decimal, cond Condition) error {
+	c.Flags |= cond
+	if d != nil {
+		*d = result
+	}
+	if trapped := cond & c.Traps; trapped != 0 {
+		return trapped
+	}
+	return nil
+}
+
+// Returns true if the exponent limits of this context are inconsistent
+func (c Context) invalid() bool {
+	return c.ExponentLimits && c.Emin > c.Emax
+}
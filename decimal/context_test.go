@@ -0,0 +1,204 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestCondition(t *testing.T) {
+	testCases := map[decimal.Condition]string{
+		0:                          "",
+		decimal.ConditionInexact:   "inexact",
+		decimal.ConditionRounded:   "rounded",
+		decimal.ConditionOverflow:  "overflow",
+		decimal.ConditionUnderflow: "underflow",
+		decimal.ConditionDivisionByZero | decimal.ConditionInvalidOperation: "division by zero, invalid operation",
+		decimal.ConditionInexact | decimal.ConditionRounded:                 "inexact, rounded",
+	}
+	for condition, expected := range testCases {
+		if actual := condition.String(); actual != expected {
+			t.Fatalf("Expected %q, instead got %q", expected, actual)
+		}
+		if actual := condition.Error(); actual != "decimal: "+expected {
+			t.Fatalf("Expected error %q, instead got %q", "decimal: "+expected, actual)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	inexact := decimal.ConditionInexact | decimal.ConditionRounded
+	testCases := map[struct {
+		x, op, y string
+		ctx      decimal.Context
+	}]struct {
+		result string
+		flags  decimal.Condition
+	}{
+		{"1", "+", "2", decimal.Context{}}:                                            {"3", 0},
+		{"1", "/", "3", decimal.Context{}}:                                            {"0.3333333333333333333", inexact},
+		{"1", "/", "3", decimal.Context{Precision: 5}}:                                {"0.33333", inexact},
+		{"2", "/", "3", decimal.Context{Precision: 5}}:                                {"0.66667", inexact},
+		{"2", "/", "3", decimal.Context{Precision: 5, Rounding: decimal.RoundDown}}:   {"0.66666", inexact},
+		{"1", "-", "0.001", decimal.Context{Precision: 3, Rounding: decimal.RoundUp}}: {"0.999", 0},
+		{"1", "-", "0.0001", decimal.Context{Precision: 3, Rounding: decimal.RoundUp}}: {
+			"1", inexact,
+		},
+		{"12000", "*", "2", decimal.Context{Precision: 2}}:                                                                      {"24000", decimal.ConditionRounded},
+		{"12300", "*", "2", decimal.Context{Precision: 2}}:                                                                      {"25000", inexact},
+		{"1200", "*", "10", decimal.Context{Precision: 2}}:                                                                      {"12000", decimal.ConditionRounded},
+		{"12", "*", "10", decimal.Context{Precision: 3}}:                                                                        {"120", 0},
+		{"1e8", "*", "1e5", decimal.Context{Emin: -10, Emax: 10}}:                                                               {"1e13", 0},
+		{"1e8", "*", "1e2", decimal.Context{ExponentLimits: true, Emin: -10, Emax: 10}}:                                         {"1e10", 0},
+		{"1e8", "*", "1e5", decimal.Context{ExponentLimits: true, Emin: -10, Emax: 10}}:                                         {"Inf", decimal.ConditionOverflow | inexact},
+		{"1e5", "*", "1e5", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                           {"Inf", decimal.ConditionOverflow | inexact},
+		{"1e4", "*", "10", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                            {"1e5", 0},
+		{"99999e2", "*", "1e5", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10}}:                                  {"Inf", decimal.ConditionOverflow | inexact},
+		{"99999e2", "*", "1e4", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10}}:                                  {"99999e6", 0},
+		{"99999e1", "*", "1e5", decimal.Context{Precision: 4, ExponentLimits: true, Emax: 10}}:                                  {"Inf", decimal.ConditionOverflow | inexact},
+		{"3", "*", "3", decimal.Context{ExponentLimits: true}}:                                                                  {"9", 0},
+		{"5", "*", "2", decimal.Context{ExponentLimits: true}}:                                                                  {"Inf", decimal.ConditionOverflow | inexact},
+		{"1", "/", "3", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                               {"0.3333333333333333333", inexact},
+		{"1", "/", "3", decimal.Context{Precision: 5, ExponentLimits: true, Emin: -5, Emax: 5}}:                                 {"0.33333", inexact},
+		{"1", "/", "4", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                               {"0.25", 0},
+		{"1e-6", "/", "3", decimal.Context{Precision: 5, ExponentLimits: true, Emin: -5, Emax: 5}}:                              {"333e-9", decimal.ConditionUnderflow | inexact},
+		{"1e-6", "/", "4", decimal.Context{Precision: 5, ExponentLimits: true, Emin: -5, Emax: 5}}:                              {"25e-8", 0},
+		{"5e-5", "*", "3e-5", decimal.Context{Precision: 5, ExponentLimits: true, Emin: -5, Emax: 5}}:                           {"2e-9", decimal.ConditionUnderflow | inexact},
+		{"1e-5", "*", "1e-5", decimal.Context{Precision: 5, ExponentLimits: true, Emin: -5, Emax: 5}}:                           {"0", decimal.ConditionUnderflow | inexact},
+		{"1e-5", "*", "1e-1", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                         {"1e-6", 0},
+		{"1e-20", "*", "1e-10", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5}}:                                       {"0", decimal.ConditionUnderflow | inexact},
+		{"1", "/", "0", decimal.Context{}}:                                                                                      {"Inf", decimal.ConditionDivisionByZero},
+		{"0", "/", "0", decimal.Context{}}:                                                                                      {"NaN", decimal.ConditionInvalidOperation},
+		{"1", "+", "1", decimal.Context{ExponentLimits: true, Emin: 1, Emax: -1}}:                                               {"0", decimal.ConditionInvalidOperation},
+		{"1", "+", "1", decimal.Context{Emin: 1, Emax: -1}}:                                                                     {"2", 0},
+		{"99999e2", "*", "1e5", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10, Rounding: decimal.RoundDown}}:     {"99999e6", decimal.ConditionOverflow | inexact},
+		{"99999e2", "*", "1e5", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10, Rounding: decimal.RoundFloor}}:    {"99999e6", decimal.ConditionOverflow | inexact},
+		{"-99999e2", "*", "1e5", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10, Rounding: decimal.RoundFloor}}:   {"-Inf", decimal.ConditionOverflow | inexact},
+		{"-99999e2", "*", "1e5", decimal.Context{Precision: 5, ExponentLimits: true, Emax: 10, Rounding: decimal.RoundCeiling}}: {"-99999e6", decimal.ConditionOverflow | inexact},
+		{"1e5", "*", "1e5", decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5, Rounding: decimal.RoundDown}}:              {"18446744073709551615e-14", decimal.ConditionOverflow | inexact},
+		{"Inf", "-", "Inf", decimal.Context{}}:                                                                                  {"NaN", decimal.ConditionInvalidOperation},
+		{"Inf", "*", "1e-5", decimal.Context{}}:                                                                                 {"Inf", 0},
+		{"-Inf", "round", "", decimal.Context{Precision: 1}}:                                                                    {"-Inf", 0},
+		{"NaN", "round", "", decimal.Context{Precision: 1}}:                                                                     {"NaN", 0},
+		{"123.45", "round", "", decimal.Context{Precision: 4}}:                                                                  {"123.4", inexact},
+		{"123.45", "round", "", decimal.Context{Precision: 4, ExponentLimits: true, Emin: -1, Emax: 5}}:                         {"123.4", inexact},
+		{"0.012345", "round", "", decimal.Context{Precision: 4, ExponentLimits: true, Emin: -1, Emax: 5}}:                       {"0.0123", inexact | decimal.ConditionUnderflow},
+		{"0.0125", "round", "", decimal.Context{Precision: 4, ExponentLimits: true, Emin: -1, Emax: 5}}:                         {"0.0125", 0},
+		{"123.45", "round", "", decimal.Context{Precision: 4, ExponentLimits: true, Emin: -1, Emax: 1}}:                         {"Inf", decimal.ConditionOverflow | inexact},
+		{"-123.45", "round", "", decimal.Context{Rounding: decimal.RoundUp}}:                                                    {"-123.45", 0},
+	}
+	for test, expected := range testCases {
+		x, err := decimal.ParseString(test.x)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		y, err := decimal.ParseString(test.y)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		ctx := test.ctx
+		result := decimal.Decimal{}
+		switch test.op {
+		case "+":
+			err = ctx.Add(&result, x, y)
+		case "-":
+			err = ctx.Sub(&result, x, y)
+		case "*":
+			err = ctx.Mult(&result, x, y)
+		case "/":
+			err = ctx.Div(&result, x, y)
+		case "round":
+			err = ctx.Round(&result, x)
+		}
+		if err != nil {
+			t.Fatalf("%s %s %s returned an error without traps: %v", test.x, test.op, test.y, err)
+		}
+		if !result.Equals(mustParse(t, expected.result)) || ctx.Flags != expected.flags {
+			t.Fatalf("%s %s %s with %+v expected (%s, %v), instead got (%s, %v)", test.x, test.op, test.y, test.ctx,
+				expected.result, expected.flags, result.FormatText(true, false, 0), ctx.Flags)
+		}
+	}
+}
+
+func TestContextTraps(t *testing.T) {
+	one, three := decimal.DecimalFromInt(1), decimal.DecimalFromInt(3)
+	ctx := decimal.Context{Precision: 10, Traps: decimal.ConditionInexact | decimal.ConditionDivisionByZero}
+	result := decimal.Decimal{}
+	// Exact operations don't raise anything
+	if err := ctx.Add(&result, one, three); err != nil || ctx.Flags != 0 || !result.Equals(decimal.DecimalFromInt(4)) {
+		t.Fatalf("Expected 1 + 3 to be exact, instead got (%v, %v, %v)", result, err, ctx.Flags)
+	}
+	// Only the trapped conditions are returned, but all of them are recorded
+	err := ctx.Div(&result, one, three)
+	if !errors.Is(err, decimal.ConditionInexact) || ctx.Flags != decimal.ConditionInexact|decimal.ConditionRounded {
+		t.Fatalf("Expected 1 / 3 to trap the inexact condition, instead got (%v, %v)", err, ctx.Flags)
+	}
+	if !result.Equals(decimal.Decimal{Sign: true, Value: 3333333333, PowerOfTen: -10}) {
+		t.Fatalf("Expected the result to be stored even if trapped, instead got %v", result)
+	}
+	// Flags are sticky
+	if err := ctx.Mult(nil, one, three); err != nil || ctx.Flags != decimal.ConditionInexact|decimal.ConditionRounded {
+		t.Fatalf("Expected 1 * 3 to be exact and keep the flags, instead got (%v, %v)", err, ctx.Flags)
+	}
+	if err := ctx.Div(&result, one, decimal.Decimal{}); err != decimal.ConditionDivisionByZero {
+		t.Fatalf("Expected 1 / 0 to trap the division by zero, instead got %v", err)
+	}
+	if err := ctx.Sub(&result, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, one); err != decimal.ConditionInexact {
+		t.Fatalf("Expected 1e%d - 1 to trap the inexact condition, instead got %v", int64(math.MaxInt64), err)
+	}
+	// Trapped conditions can be matched against the aritmetic errors
+	ctx.Traps |= decimal.ConditionOverflow
+	err = ctx.Mult(&result, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 1})
+	if err != nil {
+		t.Fatalf("Expected 1e%d * 10 to be exact, instead got %v", int64(math.MaxInt64), err)
+	}
+	err = ctx.Mult(&result, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: 1})
+	if !errors.Is(err, decimal.ErrOverflow) || errors.Is(err, decimal.ErrUnderflow) || errors.Is(err, decimal.ErrDivisionByZero) {
+		t.Fatalf("Expected the overflow to match ErrOverflow, instead got %v", err)
+	}
+	expected := decimal.ConditionInexact | decimal.ConditionRounded | decimal.ConditionDivisionByZero | decimal.ConditionOverflow
+	if ctx.Flags != expected {
+		t.Fatalf("Expected flags %v, instead got %v", expected, ctx.Flags)
+	}
+	// The exponent limits apply to the adjusted exponent of the result
+	ctx = decimal.Context{ExponentLimits: true, Emin: -5, Emax: 5, Traps: decimal.ConditionOverflow}
+	if err := ctx.Mult(&result, decimal.Decimal{Sign: true, Value: 100000}, decimal.Decimal{Sign: true, Value: 100000}); !errors.Is(err, decimal.ErrOverflow) {
+		t.Fatalf("Expected 1e5 * 1e5 to overflow, instead got (%v, %v)", result, err)
+	}
+	// The largest finite number might have less digits if its power of ten would be below math.MinInt64
+	ctx = decimal.Context{ExponentLimits: true, Emin: math.MinInt64, Emax: math.MinInt64 + 10, Rounding: decimal.RoundDown}
+	if err := ctx.Round(&result, decimal.Decimal{Sign: false, Value: 1, PowerOfTen: math.MinInt64 + 11}); err != nil ||
+		result != (decimal.Decimal{Sign: false, Value: 99999999999, PowerOfTen: math.MinInt64}) {
+		t.Fatalf("Expected the largest finite number, instead got (%v, %v)", result, err)
+	}
+	// An invalid context doesn't touch the result
+	ctx = decimal.Context{ExponentLimits: true, Emin: 1, Emax: 0, Traps: decimal.ConditionInvalidOperation}
+	result = one
+	if err := ctx.Round(&result, three); err != decimal.ConditionInvalidOperation || !result.Equals(one) {
+		t.Fatalf("Expected an invalid context to return an error, instead got (%v, %v)", result, err)
+	}
+}
+
+func BenchmarkContext(b *testing.B) {
+	x := decimal.Decimal{Sign: true, Value: 12345678, PowerOfTen: -4}
+	y := decimal.Decimal{Sign: false, Value: 98765, PowerOfTen: -2}
+	ctx := decimal.Context{Precision: 10, Rounding: decimal.RoundHalfUp}
+	result := decimal.Decimal{}
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx.Add(&result, x, y)
+		}
+	})
+	b.Run("Mult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx.Mult(&result, x, y)
+		}
+	})
+	b.Run("Div", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx.Div(&result, x, y)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package decimal
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrorUnknownCurrency = errors.New("the given currency is not an ISO 4217 currency code")
+)
+
+// Describes an ISO 4217 currency
+type Currency struct {
+	// The alphabetic code, like "USD"
+	Code string
+	// The numeric code, like 840 for USD
+	Numeric int
+	// The number of digits after the decimal separator, like 2 for USD and 0 for JPY.
+	// It's -1 for the codes that have no minor unit (like XAU for gold)
+	MinorUnits int
+	// The symbol, like "$" for USD, or the code if the currency has no common symbol
+	Symbol string
+}
+
+// Returns the ISO 4217 currency with the given alphabetic code (ignoring case) and true,
+// or an empty Currency and false if there is no such currency
+//
+// Examples:
+//  1. LookupCurrency("USD"): {"USD", 840, 2, "$"}, true
+//  2. LookupCurrency("kwd"): {"KWD", 414, 3, "KWD"}, true
+func LookupCurrency(code string) (Currency, bool) {
+	code = strings.ToUpper(code)
+	i := sort.Search(len(currencies), func(i int) bool { return currencies[i].Code >= code })
+	if i < len(currencies) && currencies[i].Code == code {
+		return currencies[i], true
+	}
+	return Currency{}, false
+}
+
+// Returns the ISO 4217 currency with the given numeric code and true,
+// or an empty Currency and false if there is no such currency
+func LookupCurrencyNumeric(numeric int) (Currency, bool) {
+	for _, currency := range currencies {
+		if currency.Numeric == numeric {
+			return currency, true
+		}
+	}
+	return Currency{}, false
+}
+
+// Formats an amount in the given currency and locale: the amount is rounded to the minor units of the currency
+// with the given mode, and written with the Currency pattern of the locale. Negative amounts are then written with
+// its Negative pattern, so a locale with Negative set to "(n)" writes accounting-style negatives like "($1,234.50)".
+// An amount that is zero after rounding is never negative, and NaN and infinities are formatted like String.
+// Returns ErrorUnknownCurrency if code is not an ISO 4217 currency code.
+//
+// Examples:
+//  1. -1234.567, "USD", LocaleUS: "-$1,234.57"
+//  2. 1234.5, "JPY", LocaleUS: "¥1,234" (with RoundHalfEven)
+//  3. 1234.5, "EUR", LocaleDE: "1.234,50 €" (with a no-break space)
+//  4. -1.2345, "KWD", Locale{Negative: "(n)", Currency: "n ¤"}: "(1.234 KWD)" (with RoundHalfEven)
+func FormatCurrency(d Decimal, code string, loc Locale, mode RoundingMode) (string, error) {
+	currency, ok := LookupCurrency(code)
+	if !ok {
+		return "", ErrorUnknownCurrency
+	}
+	if !d.IsFinite() {
+		return d.String(), nil
+	}
+	number, negative := formatLocaleNumber(d, loc, currency.MinorUnits, mode)
+	// Note: the number is written first, since the symbol might contain an "n"
+	amount := strings.Replace(applyPattern(loc.Currency, "¤n", number), "¤", currency.Symbol, 1)
+	if negative {
+		amount = applyPattern(loc.Negative, "-n", amount)
+	}
+	return amount, nil
+}
+
+// The ISO 4217 currencies, sorted by code
+var currencies = [...]Currency{
+	{Code: "AED", Numeric: 784, MinorUnits: 2, Symbol: "AED"},
+	{Code: "AFN", Numeric: 971, MinorUnits: 2, Symbol: "AFN"},
+	{Code: "ALL", Numeric: 8, MinorUnits: 2, Symbol: "ALL"},
+	{Code: "AMD", Numeric: 51, MinorUnits: 2, Symbol: "AMD"},
+	{Code: "AOA", Numeric: 973, MinorUnits: 2, Symbol: "AOA"},
+	{Code: "ARS", Numeric: 32, MinorUnits: 2, Symbol: "ARS"},
+	{Code: "AUD", Numeric: 36, MinorUnits: 2, Symbol: "A$"},
+	{Code: "AWG", Numeric: 533, MinorUnits: 2, Symbol: "AWG"},
+	{Code: "AZN", Numeric: 944, MinorUnits: 2, Symbol: "AZN"},
+	{Code: "BAM", Numeric: 977, MinorUnits: 2, Symbol: "BAM"},
+	{Code: "BBD", Numeric: 52, MinorUnits: 2, Symbol: "BBD"},
+	{Code: "BDT", Numeric: 50, MinorUnits: 2, Symbol: "BDT"},
+	{Code: "BGN", Numeric: 975, MinorUnits: 2, Symbol: "BGN"},
+	{Code: "BHD", Numeric: 48, MinorUnits: 3, Symbol: "BHD"},
+	{Code: "BIF", Numeric: 108, MinorUnits: 0, Symbol: "BIF"},
+	{Code: "BMD", Numeric: 60, MinorUnits: 2, Symbol: "BMD"},
+	{Code: "BND", Numeric: 96, MinorUnits: 2, Symbol: "BND"},
+	{Code: "BOB", Numeric: 68, MinorUnits: 2, Symbol: "BOB"},
+	{Code: "BOV", Numeric: 984, MinorUnits: 2, Symbol: "BOV"},
+	{Code: "BRL", Numeric: 986, MinorUnits: 2, Symbol: "R$"},
+	{Code: "BSD", Numeric: 44, MinorUnits: 2, Symbol: "BSD"},
+	{Code: "BTN", Numeric: 64, MinorUnits: 2, Symbol: "BTN"},
+	{Code: "BWP", Numeric: 72, MinorUnits: 2, Symbol: "BWP"},
+	{Code: "BYN", Numeric: 933, MinorUnits: 2, Symbol: "BYN"},
+	{Code: "BZD", Numeric: 84, MinorUnits: 2, Symbol: "BZD"},
+	{Code: "CAD", Numeric: 124, MinorUnits: 2, Symbol: "CA$"},
+	{Code: "CDF", Numeric: 976, MinorUnits: 2, Symbol: "CDF"},
+	{Code: "CHE", Numeric: 947, MinorUnits: 2, Symbol: "CHE"},
+	{Code: "CHF", Numeric: 756, MinorUnits: 2, Symbol: "CHF"},
+	{Code: "CHW", Numeric: 948, MinorUnits: 2, Symbol: "CHW"},
+	{Code: "CLF", Numeric: 990, MinorUnits: 4, Symbol: "CLF"},
+	{Code: "CLP", Numeric: 152, MinorUnits: 0, Symbol: "CLP"},
+	{Code: "CNY", Numeric: 156, MinorUnits: 2, Symbol: "CN¥"},
+	{Code: "COP", Numeric: 170, MinorUnits: 2, Symbol: "COP"},
+	{Code: "COU", Numeric: 970, MinorUnits: 2, Symbol: "COU"},
+	{Code: "CRC", Numeric: 188, MinorUnits: 2, Symbol: "CRC"},
+	{Code: "CUP", Numeric: 192, MinorUnits: 2, Symbol: "CUP"},
+	{Code: "CVE", Numeric: 132, MinorUnits: 2, Symbol: "CVE"},
+	{Code: "CZK", Numeric: 203, MinorUnits: 2, Symbol: "CZK"},
+	{Code: "DJF", Numeric: 262, MinorUnits: 0, Symbol: "DJF"},
+	{Code: "DKK", Numeric: 208, MinorUnits: 2, Symbol: "DKK"},
+	{Code: "DOP", Numeric: 214, MinorUnits: 2, Symbol: "DOP"},
+	{Code: "DZD", Numeric: 12, MinorUnits: 2, Symbol: "DZD"},
+	{Code: "EGP", Numeric: 818, MinorUnits: 2, Symbol: "EGP"},
+	{Code: "ERN", Numeric: 232, MinorUnits: 2, Symbol: "ERN"},
+	{Code: "ETB", Numeric: 230, MinorUnits: 2, Symbol: "ETB"},
+	{Code: "EUR", Numeric: 978, MinorUnits: 2, Symbol: "€"},
+	{Code: "FJD", Numeric: 242, MinorUnits: 2, Symbol: "FJD"},
+	{Code: "FKP", Numeric: 238, MinorUnits: 2, Symbol: "FKP"},
+	{Code: "GBP", Numeric: 826, MinorUnits: 2, Symbol: "£"},
+	{Code: "GEL", Numeric: 981, MinorUnits: 2, Symbol: "GEL"},
+	{Code: "GHS", Numeric: 936, MinorUnits: 2, Symbol: "GHS"},
+	{Code: "GIP", Numeric: 292, MinorUnits: 2, Symbol: "GIP"},
+	{Code: "GMD", Numeric: 270, MinorUnits: 2, Symbol: "GMD"},
+	{Code: "GNF", Numeric: 324, MinorUnits: 0, Symbol: "GNF"},
+	{Code: "GTQ", Numeric: 320, MinorUnits: 2, Symbol: "GTQ"},
+	{Code: "GYD", Numeric: 328, MinorUnits: 2, Symbol: "GYD"},
+	{Code: "HKD", Numeric: 344, MinorUnits: 2, Symbol: "HK$"},
+	{Code: "HNL", Numeric: 340, MinorUnits: 2, Symbol: "HNL"},
+	{Code: "HTG", Numeric: 332, MinorUnits: 2, Symbol: "HTG"},
+	{Code: "HUF", Numeric: 348, MinorUnits: 2, Symbol: "HUF"},
+	{Code: "IDR", Numeric: 360, MinorUnits: 2, Symbol: "IDR"},
+	{Code: "ILS", Numeric: 376, MinorUnits: 2, Symbol: "₪"},
+	{Code: "INR", Numeric: 356, MinorUnits: 2, Symbol: "₹"},
+	{Code: "IQD", Numeric: 368, MinorUnits: 3, Symbol: "IQD"},
+	{Code: "IRR", Numeric: 364, MinorUnits: 2, Symbol: "IRR"},
+	{Code: "ISK", Numeric: 352, MinorUnits: 0, Symbol: "ISK"},
+	{Code: "JMD", Numeric: 388, MinorUnits: 2, Symbol: "JMD"},
+	{Code: "JOD", Numeric: 400, MinorUnits: 3, Symbol: "JOD"},
+	{Code: "JPY", Numeric: 392, MinorUnits: 0, Symbol: "¥"},
+	{Code: "KES", Numeric: 404, MinorUnits: 2, Symbol: "KES"},
+	{Code: "KGS", Numeric: 417, MinorUnits: 2, Symbol: "KGS"},
+	{Code: "KHR", Numeric: 116, MinorUnits: 2, Symbol: "KHR"},
+	{Code: "KMF", Numeric: 174, MinorUnits: 0, Symbol: "KMF"},
+	{Code: "KPW", Numeric: 408, MinorUnits: 2, Symbol: "KPW"},
+	{Code: "KRW", Numeric: 410, MinorUnits: 0, Symbol: "₩"},
+	{Code: "KWD", Numeric: 414, MinorUnits: 3, Symbol: "KWD"},
+	{Code: "KYD", Numeric: 136, MinorUnits: 2, Symbol: "KYD"},
+	{Code: "KZT", Numeric: 398, MinorUnits: 2, Symbol: "KZT"},
+	{Code: "LAK", Numeric: 418, MinorUnits: 2, Symbol: "LAK"},
+	{Code: "LBP", Numeric: 422, MinorUnits: 2, Symbol: "LBP"},
+	{Code: "LKR", Numeric: 144, MinorUnits: 2, Symbol: "LKR"},
+	{Code: "LRD", Numeric: 430, MinorUnits: 2, Symbol: "LRD"},
+	{Code: "LSL", Numeric: 426, MinorUnits: 2, Symbol: "LSL"},
+	{Code: "LYD", Numeric: 434, MinorUnits: 3, Symbol: "LYD"},
+	{Code: "MAD", Numeric: 504, MinorUnits: 2, Symbol: "MAD"},
+	{Code: "MDL", Numeric: 498, MinorUnits: 2, Symbol: "MDL"},
+	{Code: "MGA", Numeric: 969, MinorUnits: 2, Symbol: "MGA"},
+	{Code: "MKD", Numeric: 807, MinorUnits: 2, Symbol: "MKD"},
+	{Code: "MMK", Numeric: 104, MinorUnits: 2, Symbol: "MMK"},
+	{Code: "MNT", Numeric: 496, MinorUnits: 2, Symbol: "MNT"},
+	{Code: "MOP", Numeric: 446, MinorUnits: 2, Symbol: "MOP"},
+	{Code: "MRU", Numeric: 929, MinorUnits: 2, Symbol: "MRU"},
+	{Code: "MUR", Numeric: 480, MinorUnits: 2, Symbol: "MUR"},
+	{Code: "MVR", Numeric: 462, MinorUnits: 2, Symbol: "MVR"},
+	{Code: "MWK", Numeric: 454, MinorUnits: 2, Symbol: "MWK"},
+	{Code: "MXN", Numeric: 484, MinorUnits: 2, Symbol: "MX$"},
+	{Code: "MXV", Numeric: 979, MinorUnits: 2, Symbol: "MXV"},
+	{Code: "MYR", Numeric: 458, MinorUnits: 2, Symbol: "MYR"},
+	{Code: "MZN", Numeric: 943, MinorUnits: 2, Symbol: "MZN"},
+	{Code: "NAD", Numeric: 516, MinorUnits: 2, Symbol: "NAD"},
+	{Code: "NGN", Numeric: 566, MinorUnits: 2, Symbol: "NGN"},
+	{Code: "NIO", Numeric: 558, MinorUnits: 2, Symbol: "NIO"},
+	{Code: "NOK", Numeric: 578, MinorUnits: 2, Symbol: "NOK"},
+	{Code: "NPR", Numeric: 524, MinorUnits: 2, Symbol: "NPR"},
+	{Code: "NZD", Numeric: 554, MinorUnits: 2, Symbol: "NZ$"},
+	{Code: "OMR", Numeric: 512, MinorUnits: 3, Symbol: "OMR"},
+	{Code: "PAB", Numeric: 590, MinorUnits: 2, Symbol: "PAB"},
+	{Code: "PEN", Numeric: 604, MinorUnits: 2, Symbol: "PEN"},
+	{Code: "PGK", Numeric: 598, MinorUnits: 2, Symbol: "PGK"},
+	{Code: "PHP", Numeric: 608, MinorUnits: 2, Symbol: "₱"},
+	{Code: "PKR", Numeric: 586, MinorUnits: 2, Symbol: "PKR"},
+	{Code: "PLN", Numeric: 985, MinorUnits: 2, Symbol: "PLN"},
+	{Code: "PYG", Numeric: 600, MinorUnits: 0, Symbol: "PYG"},
+	{Code: "QAR", Numeric: 634, MinorUnits: 2, Symbol: "QAR"},
+	{Code: "RON", Numeric: 946, MinorUnits: 2, Symbol: "RON"},
+	{Code: "RSD", Numeric: 941, MinorUnits: 2, Symbol: "RSD"},
+	{Code: "RUB", Numeric: 643, MinorUnits: 2, Symbol: "RUB"},
+	{Code: "RWF", Numeric: 646, MinorUnits: 0, Symbol: "RWF"},
+	{Code: "SAR", Numeric: 682, MinorUnits: 2, Symbol: "SAR"},
+	{Code: "SBD", Numeric: 90, MinorUnits: 2, Symbol: "SBD"},
+	{Code: "SCR", Numeric: 690, MinorUnits: 2, Symbol: "SCR"},
+	{Code: "SDG", Numeric: 938, MinorUnits: 2, Symbol: "SDG"},
+	{Code: "SEK", Numeric: 752, MinorUnits: 2, Symbol: "SEK"},
+	{Code: "SGD", Numeric: 702, MinorUnits: 2, Symbol: "SGD"},
+	{Code: "SHP", Numeric: 654, MinorUnits: 2, Symbol: "SHP"},
+	{Code: "SLE", Numeric: 925, MinorUnits: 2, Symbol: "SLE"},
+	{Code: "SOS", Numeric: 706, MinorUnits: 2, Symbol: "SOS"},
+	{Code: "SRD", Numeric: 968, MinorUnits: 2, Symbol: "SRD"},
+	{Code: "SSP", Numeric: 728, MinorUnits: 2, Symbol: "SSP"},
+	{Code: "STN", Numeric: 930, MinorUnits: 2, Symbol: "STN"},
+	{Code: "SVC", Numeric: 222, MinorUnits: 2, Symbol: "SVC"},
+	{Code: "SYP", Numeric: 760, MinorUnits: 2, Symbol: "SYP"},
+	{Code: "SZL", Numeric: 748, MinorUnits: 2, Symbol: "SZL"},
+	{Code: "THB", Numeric: 764, MinorUnits: 2, Symbol: "THB"},
+	{Code: "TJS", Numeric: 972, MinorUnits: 2, Symbol: "TJS"},
+	{Code: "TMT", Numeric: 934, MinorUnits: 2, Symbol: "TMT"},
+	{Code: "TND", Numeric: 788, MinorUnits: 3, Symbol: "TND"},
+	{Code: "TOP", Numeric: 776, MinorUnits: 2, Symbol: "TOP"},
+	{Code: "TRY", Numeric: 949, MinorUnits: 2, Symbol: "TRY"},
+	{Code: "TTD", Numeric: 780, MinorUnits: 2, Symbol: "TTD"},
+	{Code: "TWD", Numeric: 901, MinorUnits: 2, Symbol: "NT$"},
+	{Code: "TZS", Numeric: 834, MinorUnits: 2, Symbol: "TZS"},
+	{Code: "UAH", Numeric: 980, MinorUnits: 2, Symbol: "UAH"},
+	{Code: "UGX", Numeric: 800, MinorUnits: 0, Symbol: "UGX"},
+	{Code: "USD", Numeric: 840, MinorUnits: 2, Symbol: "$"},
+	{Code: "USN", Numeric: 997, MinorUnits: 2, Symbol: "USN"},
+	{Code: "UYI", Numeric: 940, MinorUnits: 0, Symbol: "UYI"},
+	{Code: "UYU", Numeric: 858, MinorUnits: 2, Symbol: "UYU"},
+	{Code: "UYW", Numeric: 927, MinorUnits: 4, Symbol: "UYW"},
+	{Code: "UZS", Numeric: 860, MinorUnits: 2, Symbol: "UZS"},
+	{Code: "VED", Numeric: 926, MinorUnits: 2, Symbol: "VED"},
+	{Code: "VES", Numeric: 928, MinorUnits: 2, Symbol: "VES"},
+	{Code: "VND", Numeric: 704, MinorUnits: 0, Symbol: "₫"},
+	{Code: "VUV", Numeric: 548, MinorUnits: 0, Symbol: "VUV"},
+	{Code: "WST", Numeric: 882, MinorUnits: 2, Symbol: "WST"},
+	{Code: "XAF", Numeric: 950, MinorUnits: 0, Symbol: "FCFA"},
+	{Code: "XAG", Numeric: 961, MinorUnits: -1, Symbol: "XAG"},
+	{Code: "XAU", Numeric: 959, MinorUnits: -1, Symbol: "XAU"},
+	{Code: "XBA", Numeric: 955, MinorUnits: -1, Symbol: "XBA"},
+	{Code: "XBB", Numeric: 956, MinorUnits: -1, Symbol: "XBB"},
+	{Code: "XBC", Numeric: 957, MinorUnits: -1, Symbol: "XBC"},
+	{Code: "XBD", Numeric: 958, MinorUnits: -1, Symbol: "XBD"},
+	{Code: "XCD", Numeric: 951, MinorUnits: 2, Symbol: "EC$"},
+	{Code: "XCG", Numeric: 532, MinorUnits: 2, Symbol: "XCG"},
+	{Code: "XDR", Numeric: 960, MinorUnits: -1, Symbol: "XDR"},
+	{Code: "XOF", Numeric: 952, MinorUnits: 0, Symbol: "F\u202fCFA"},
+	{Code: "XPD", Numeric: 964, MinorUnits: -1, Symbol: "XPD"},
+	{Code: "XPF", Numeric: 953, MinorUnits: 0, Symbol: "CFPF"},
+	{Code: "XPT", Numeric: 962, MinorUnits: -1, Symbol: "XPT"},
+	{Code: "XSU", Numeric: 994, MinorUnits: -1, Symbol: "XSU"},
+	{Code: "XTS", Numeric: 963, MinorUnits: -1, Symbol: "XTS"},
+	{Code: "XUA", Numeric: 965, MinorUnits: -1, Symbol: "XUA"},
+	{Code: "XXX", Numeric: 999, MinorUnits: -1, Symbol: "XXX"},
+	{Code: "YER", Numeric: 886, MinorUnits: 2, Symbol: "YER"},
+	{Code: "ZAR", Numeric: 710, MinorUnits: 2, Symbol: "ZAR"},
+	{Code: "ZMW", Numeric: 967, MinorUnits: 2, Symbol: "ZMW"},
+	{Code: "ZWG", Numeric: 924, MinorUnits: 2, Symbol: "ZWG"},
+}
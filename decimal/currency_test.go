@@ -0,0 +1,124 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	testCases := map[string]decimal.Currency{
+		"USD": {Code: "USD", Numeric: 840, MinorUnits: 2, Symbol: "$"},
+		"eur": {Code: "EUR", Numeric: 978, MinorUnits: 2, Symbol: "€"},
+		"JPY": {Code: "JPY", Numeric: 392, MinorUnits: 0, Symbol: "¥"},
+		"KWD": {Code: "KWD", Numeric: 414, MinorUnits: 3, Symbol: "KWD"},
+		"CLF": {Code: "CLF", Numeric: 990, MinorUnits: 4, Symbol: "CLF"},
+		"ALL": {Code: "ALL", Numeric: 8, MinorUnits: 2, Symbol: "ALL"},
+		"ZWG": {Code: "ZWG", Numeric: 924, MinorUnits: 2, Symbol: "ZWG"},
+		"XAU": {Code: "XAU", Numeric: 959, MinorUnits: -1, Symbol: "XAU"},
+		"AAA": {},
+		"US":  {},
+		"":    {},
+	}
+
+	for code, expected := range testCases {
+		currency, ok := decimal.LookupCurrency(code)
+		if currency != expected || ok != (expected.Code != "") {
+			t.Fatalf("LookupCurrency(%q) expected (%+v, %v), instead got (%+v, %v)", code, expected, expected.Code != "", currency, ok)
+		}
+		if expected.Code == "" {
+			continue
+		}
+		if currency, ok := decimal.LookupCurrencyNumeric(expected.Numeric); !ok || currency != expected {
+			t.Fatalf("LookupCurrencyNumeric(%d) expected %+v, instead got (%+v, %v)", expected.Numeric, expected, currency, ok)
+		}
+	}
+	if currency, ok := decimal.LookupCurrencyNumeric(0); ok {
+		t.Fatalf("LookupCurrencyNumeric(0) expected no currency, instead got %+v", currency)
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	accounting := decimal.LocaleUS
+	accounting.Negative = "(n)"
+	testCases := map[struct {
+		number string
+		code   string
+		locale string
+		mode   decimal.RoundingMode
+	}]string{
+		{"-1234.567", "USD", "en-US", decimal.RoundHalfEven}:   "-$1,234.57",
+		{"-1234.567", "USD", "accounting", decimal.RoundDown}:  "($1,234.56)",
+		{"1234.5", "JPY", "en-US", decimal.RoundHalfEven}:      "¥1,234",
+		{"1234.5", "JPY", "en-US", decimal.RoundHalfUp}:        "¥1,235",
+		{"1234.5", "EUR", "de-DE", decimal.RoundHalfEven}:      "1.234,50\u00a0€",
+		{"-1234.5", "EUR", "fr-FR", decimal.RoundHalfEven}:     "-1\u202f234,50\u00a0€",
+		{"1234.5", "CHF", "de-CH", decimal.RoundHalfEven}:      "CHF\u00a01’234.50",
+		{"1234567.891", "INR", "en-IN", decimal.RoundHalfEven}: "₹12,34,567.89",
+		{"1234.5", "BRL", "pt-BR", decimal.RoundHalfEven}:      "R$\u00a01.234,50",
+		{"-1.2345", "KWD", "custom", decimal.RoundHalfEven}:    "(1.234 KWD)",
+		{"-1.2345", "kwd", "custom", decimal.RoundCeiling}:     "(1.234 KWD)",
+		{"-1.2345", "KWD", "custom", decimal.RoundFloor}:       "(1.235 KWD)",
+		{"0.12345", "CLF", "en-US", decimal.RoundHalfEven}:     "CLF0.1234",
+		{"0.123456789", "XAU", "en-US", decimal.RoundHalfEven}: "XAU0.123456789",
+		{"-0.001", "USD", "accounting", decimal.RoundHalfEven}: "$0.00",
+		{"-0.001", "USD", "accounting", decimal.RoundUp}:       "($0.01)",
+		{"5", "XOF", "fr-FR", decimal.RoundHalfEven}:           "5\u00a0F\u202fCFA",
+		{"NaN", "USD", "en-US", decimal.RoundHalfEven}:         "NaN",
+		{"-Inf", "USD", "accounting", decimal.RoundHalfEven}:   "-Inf",
+	}
+	locales := map[string]decimal.Locale{
+		"accounting": accounting,
+		"custom":     {Negative: "(n)", Currency: "n ¤"},
+	}
+	for test, expected := range testCases {
+		number := mustParse(t, test.number)
+		locale, ok := decimal.Locales[test.locale]
+		if !ok {
+			locale = locales[test.locale]
+		}
+		if actual, err := decimal.FormatCurrency(number, test.code, locale, test.mode); err != nil || actual != expected {
+			t.Fatalf("FormatCurrency(%v, %q, %s, %d) expected %q, instead got (%q, %v)", number, test.code, test.locale, test.mode, expected, actual, err)
+		}
+	}
+	// Unknown currencies
+	if actual, err := decimal.FormatCurrency(mustParse(t, "1"), "ABC", decimal.LocaleUS, decimal.RoundHalfEven); !errors.Is(err, decimal.ErrorUnknownCurrency) {
+		t.Fatalf("FormatCurrency with an unknown currency expected ErrorUnknownCurrency, instead got (%q, %v)", actual, err)
+	}
+}
+
+func BenchmarkFormatCurrency(b *testing.B) {
+	number := decimal.Decimal{Sign: false, Value: 1234567, PowerOfTen: -3}
+	for i := 0; i < b.N; i++ {
+		decimal.FormatCurrency(number, "EUR", decimal.LocaleDE, decimal.RoundHalfEven)
+	}
+}
+
+func FuzzFormatCurrency(f *testing.F) {
+	f.Add(false, uint64(1234567), int16(-3), "USD", uint8(0))
+	f.Add(true, uint64(12345), int16(-1), "EUR", uint8(1))
+	f.Add(true, uint64(18446744073709551615), int16(-4), "KWD", uint8(4))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int16, code string, localeIndex uint8) {
+		locale := []decimal.Locale{decimal.LocaleUS, decimal.LocaleDE, decimal.LocaleFR, decimal.LocaleCH, decimal.LocaleIN}[localeIndex%5]
+		number := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		amount, err := decimal.FormatCurrency(number, code, locale, decimal.RoundHalfEven)
+		currency, ok := decimal.LookupCurrency(code)
+		if !ok {
+			if !errors.Is(err, decimal.ErrorUnknownCurrency) {
+				t.Fatalf("FormatCurrency(%v, %q) expected ErrorUnknownCurrency, instead got (%q, %v)", number, code, amount, err)
+			}
+			return
+		}
+		// It parses back to the rounded amount and the currency
+		expected := number.Clone()
+		if currency.MinorUnits >= 0 {
+			expected.Round(int64(currency.MinorUnits), decimal.RoundHalfEven)
+		}
+		parser := decimal.AmountParser{Locale: locale, Currencies: []string{currency.Symbol}}
+		parsed, symbol, err := parser.Parse(amount)
+		if err != nil || !parsed.Equals(expected) || symbol != currency.Symbol {
+			t.Fatalf("FormatCurrency(%v, %q) returned %q, which parses as (%v, %q, %v) instead of %v", number, code, amount, parsed, symbol, err, expected)
+		}
+	})
+}
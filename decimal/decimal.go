@@ -1,12 +1,37 @@
 package decimal
 
-// A representation of a decimal number in scientific notation
+import "math"
+
+// A representation of a decimal number in scientific notation.
+// Can also hold the special values NaN (not a number) and ±infinity, see NaN and Inf.
+//
+// Two encodings are reserved for them: a zero Value with a PowerOfTen of math.MaxInt64 is NaN,
+// while a zero Value with a PowerOfTen of math.MinInt64 is an infinity with the given Sign.
+// Operations never give those powers of ten to a zero, so zeroes built by hand should use any other
+// PowerOfTen (usually 0).
 type Decimal struct {
 	Sign       bool
 	Value      uint64
 	PowerOfTen int64
 }
 
+// The powers of ten that mark a zero Value as a special value
+const (
+	nanPowerOfTen      = math.MaxInt64
+	infinityPowerOfTen = math.MinInt64
+)
+
+// Returns a quiet NaN (not a number), the result of operations like 0/0 or ∞-∞.
+// Operations involving a NaN always return NaN.
+func NaN() Decimal {
+	return Decimal{Sign: true, PowerOfTen: nanPowerOfTen}
+}
+
+// Returns positive infinity if sign is true, negative infinity otherwise
+func Inf(sign bool) Decimal {
+	return Decimal{Sign: sign, PowerOfTen: infinityPowerOfTen}
+}
+
 // Returns a decimal based on a given int64
 func DecimalFromInt(i int64) Decimal {
 	positive := i >= 0
@@ -0,0 +1,462 @@
+package decimal
+
+import (
+	"math"
+	"math/bits"
+)
+
+// A representation of a decimal number in scientific notation with a 128 bit Value,
+// split in its High and Low 64 bits. Can store at least 38 significant digits.
+type Decimal128 struct {
+	Sign       bool
+	High       uint64
+	Low        uint64
+	PowerOfTen int64
+}
+
+// Returns a Decimal128 with the same value of a given Decimal.
+// NaN and infinities can't be represented: the result is zero and ok is false
+func Decimal128FromDecimal(d Decimal) (result Decimal128, ok bool) {
+	if !d.IsFinite() {
+		return Decimal128{Sign: d.Sign}, false
+	}
+	return Decimal128{
+		Sign:       d.Sign,
+		High:       0,
+		Low:        d.Value,
+		PowerOfTen: d.PowerOfTen,
+	}, true
+}
+
+// Returns the Decimal closest to this number.
+// If the number can't be represented exactly, it's rounded with RoundHalfEven and ok is false.
+func (d Decimal128) Decimal() (result Decimal, ok bool) {
+	result, cond := roundCoefficient(d.Sign, d.value(), false, newWidePower(d.PowerOfTen), Context{})
+	return result, cond&^ConditionRounded == 0
+}
+
+// Parse a 128 bit decimal number from a given string, ignoring any unknown characters.
+// Follows the same rules as ParseString, but only loses precision after 38 (sometimes 39) significant digits.
+//
+// Examples:
+//  1. "-1!23.45e-23" parses as -12345 * 10 ^ -25
+//  2. "12%" parses as 12 * 10 ^ -2
+//  3. "123456789012345678901234567890.5" parses as 1234567890123456789012345678905 * 10 ^ -1
+func ParseString128(numberStr string) (Decimal128, error) {
+	// Value (whole + decimal part)
+	value, digits := uint128{}, uint64(0)
+	powerOfTen := int64(0)
+	sign, percentage, exponent := scanNumber(numberStr, func(digit uint8, fraction bool) {
+		if digits == 0 && digit == 0 {
+			// Leading zero
+			if fraction {
+				powerOfTen--
+			}
+		} else if digits < uint64(len(powersOfTen128))-1 {
+			// Can add digit to value
+			digits++
+			if fraction {
+				powerOfTen--
+			}
+			value = value.mul64(10).add64(uint64(digit))
+		} else if digits == uint64(len(powersOfTen128))-1 && (value.cmp(maxUint128Div10) < 0 || (value == maxUint128Div10 && digit <= 5)) {
+			// Almost max precision, the digit fits
+			digits++
+			if fraction {
+				powerOfTen--
+			}
+			value = value.mul64(10).add64(uint64(digit))
+		} else {
+			// We can't add any more number to the value (max precision)
+			// Note: this can't overflow, see ParseString
+			digits = uint64(len(powersOfTen128))
+			if !fraction {
+				powerOfTen++
+			}
+		}
+	})
+	decimal := Decimal128{Sign: sign}
+	if value.isZero() {
+		return decimal, nil
+	}
+	decimal.High, decimal.Low = value.hi, value.lo
+	if percentage {
+		// 1% == 0.01 == 1e-2
+		powerOfTen -= 2
+	}
+	// Power of 10 ('E'/'e')
+	if len(exponent) > 0 {
+		exponentPower, err := parseExponent(exponent)
+		if err != nil {
+			return Decimal128{}, err
+		}
+		// Merge powers of 10
+		if overflow_int64(powerOfTen, exponentPower) {
+			return Decimal128{}, ErrorParsingOverflow
+		}
+		powerOfTen += exponentPower
+	}
+	decimal.PowerOfTen = powerOfTen
+	return decimal, nil
+}
+
+// Implementation of the TextUnmarshaler interface using the ParseString128 function
+func (d *Decimal128) UnmarshalText(text []byte) (err error) {
+	if d == nil {
+		return ErrorNilPointer
+	}
+	*d, err = ParseString128(string(text))
+	return err
+}
+
+// Formats a number as a string, with the same parameters as Decimal.FormatText
+func (d Decimal128) FormatText(asDecimal bool, asPercentage bool, accuracyLimit int) string {
+	// Prepare number
+	d.Compress()
+	return formatNumber(d.Sign, []byte(d.value().String()), d.PowerOfTen, asDecimal, asPercentage, accuracyLimit)
+}
+
+// Returns true if the number is zero
+func (d Decimal128) IsZero() bool {
+	return d.High == 0 && d.Low == 0
+}
+
+// Returns true if the number is positive
+func (d Decimal128) IsPositive() bool {
+	return d.Sign && !d.IsZero()
+}
+
+// Returns true if the number is negative
+func (d Decimal128) IsNegative() bool {
+	return !d.Sign && !d.IsZero()
+}
+
+// Returns a clone of a number
+func (d Decimal128) Clone() Decimal128 {
+	return Decimal128{
+		Sign:       d.Sign,
+		High:       d.High,
+		Low:        d.Low,
+		PowerOfTen: d.PowerOfTen,
+	}
+}
+
+// Makes the number value absolute
+func (d *Decimal128) Abs() {
+	if d == nil {
+		return
+	}
+	d.Sign = true
+}
+
+// Zeroes the number
+func (d *Decimal128) Zero() {
+	if d == nil {
+		return
+	}
+	d.Sign = true
+	d.High = 0
+	d.Low = 0
+	d.PowerOfTen = 0
+}
+
+// Increase the Value of the number compensating by adjusting it's power of ten.
+// Will not affect accuracy or precision.
+func (d *Decimal128) Expand() {
+	if d == nil {
+		return
+	} else if d.IsZero() {
+		d.PowerOfTen = 0
+		return
+	}
+	// Up to 38 digits always fit, a 39th one only if the value stays below 2^128
+	value, digits := d.value(), uint64(0)
+	if valueDigits := value.digits(); valueDigits < uint64(len(powersOfTen128))-1 {
+		digits = uint64(len(powersOfTen128)) - 1 - valueDigits
+	}
+	if value.shiftLeft(digits).cmp(maxUint128Div10) <= 0 {
+		digits++
+	}
+	if d.PowerOfTen < 0 && uint64(d.PowerOfTen-math.MinInt64) < digits {
+		digits = uint64(d.PowerOfTen - math.MinInt64)
+	}
+	value = value.shiftLeft(digits)
+	d.High, d.Low = value.hi, value.lo
+	d.PowerOfTen -= int64(digits)
+}
+
+// Compress the Value of the number compensating by adjusting it's power of ten.
+// Will not affect accuracy or precision.
+func (d *Decimal128) Compress() {
+	if d == nil {
+		return
+	} else if d.IsZero() {
+		d.PowerOfTen = 0
+		return
+	}
+	value := d.value()
+	for d.PowerOfTen < math.MaxInt64 {
+		quotient, remainder := value.divmod64(10)
+		if remainder != 0 {
+			break
+		}
+		value = quotient
+		d.PowerOfTen++
+	}
+	d.High, d.Low = value.hi, value.lo
+}
+
+// Returns true if the two decimals are equal
+func (d Decimal128) Equals(x Decimal128) bool {
+	d.Compress()
+	x.Compress()
+	return (d.IsZero() && x.IsZero()) ||
+		(d.Sign == x.Sign && d.High == x.High && d.Low == x.Low && d.PowerOfTen == x.PowerOfTen)
+}
+
+// Stores the result of an operation in this decimal, unless the operation overflowed,
+// divided by zero or was invalid: in that case the decimal is left unchanged.
+// Returns the raised conditions
+func (d *Decimal128) store(result Decimal128, cond Condition) Condition {
+	if cond&failureConditions == 0 {
+		*d = result
+	}
+	return cond
+}
+
+// Perform the addition x + y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows, the decimal is left unchanged
+func (d *Decimal128) Add(x, y Decimal128) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(add128(x, y, 0, RoundHalfEven))&^ConditionInexact == 0
+}
+
+// Perform the subtraction x - y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows, the decimal is left unchanged
+func (d *Decimal128) Sub(x, y Decimal128) (ok bool) {
+	y.Sign = !y.Sign
+	return d.Add(x, y)
+}
+
+// Perform the multiplication x * y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows, the decimal is left unchanged
+func (d *Decimal128) Mult(x, y Decimal128) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(mult128(x, y, 0, RoundHalfEven))&^ConditionInexact == 0
+}
+
+// Perform the division x / y and store the result in this decimal.
+// The result is rounded with RoundHalfEven to as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows or a divide-by-zero error is encountered, the decimal is left unchanged and will return false
+func (d *Decimal128) Div(x, y Decimal128) (ok bool) {
+	return d.DivRound(x, y, 0, RoundHalfEven)
+}
+
+// Perform the division x / y and store the result in this decimal, rounded with the given mode
+// to `precision` significant digits. A precision <= 0 keeps as many digits as fit in the decimal.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows or a divide-by-zero error is encountered, the decimal is left unchanged and will return false
+func (d *Decimal128) DivRound(x, y Decimal128, precision int, mode RoundingMode) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(div128(x, y, precision, mode))&^ConditionInexact == 0
+}
+
+// Performs the addition x + y, rounded with the given mode to `precision` significant digits
+func add128(x, y Decimal128, precision int, mode RoundingMode) (Decimal128, Condition) {
+	// Special case: zero
+	if x.IsZero() && y.IsZero() {
+		return Decimal128{Sign: x.Sign || y.Sign}, 0
+	} else if x.IsZero() {
+		return roundCoefficient128(y.Sign, y.value().wide(), false, newWidePower(y.PowerOfTen), precision, mode)
+	} else if y.IsZero() {
+		return roundCoefficient128(x.Sign, x.value().wide(), false, newWidePower(x.PowerOfTen), precision, mode)
+	}
+	// Make sure x is the number with the higher power of ten
+	if x.PowerOfTen < y.PowerOfTen {
+		x, y = y, x
+	}
+	// Bring x to the same power of ten as y, as long as it fits in 76 digits.
+	// Note: the subtraction can't overflow a uint64 since x.PowerOfTen >= y.PowerOfTen
+	delta := uint64(x.PowerOfTen) - uint64(y.PowerOfTen)
+	shift := uint64(len(powersOfTen256)) - 2 - x.value().digits()
+	if delta < shift {
+		shift = delta
+	}
+	high := x.value().wide().shiftLeft(shift)
+	// If x is too large, discard the digits of y that would not matter anyway remembering if any was non-zero.
+	// This can only happen if x has 76 digits, so we'll have to round the result regardless.
+	low, sticky := y.value().wide(), false
+	if shift < delta {
+		var last uint64
+		low, last, sticky = low.shiftDigits(delta - shift)
+		sticky = sticky || last != 0
+	}
+	power := newWidePower(x.PowerOfTen).sub(int64(shift))
+	// Perform operation
+	if x.Sign == y.Sign {
+		return roundCoefficient128(x.Sign, high.add(low), sticky, power, precision, mode)
+	}
+	if high.cmp(low) < 0 {
+		// Note: low can only be larger if no digit was discarded
+		return roundCoefficient128(y.Sign, low.sub(high), false, power, precision, mode)
+	}
+	result := high.sub(low)
+	if sticky {
+		// x - (y + ε) = (x - y - 1) + (1 - ε)
+		result = result.sub(uint256{1})
+	} else if result.isZero() {
+		return Decimal128{Sign: true}, 0
+	}
+	return roundCoefficient128(x.Sign, result, sticky, power, precision, mode)
+}
+
+// Performs the multiplication x * y, rounded with the given mode to `precision` significant digits
+func mult128(x, y Decimal128, precision int, mode RoundingMode) (Decimal128, Condition) {
+	sign := x.Sign == y.Sign
+	// Special case: zero
+	if x.IsZero() || y.IsZero() {
+		return Decimal128{Sign: sign}, 0
+	}
+	return roundCoefficient128(sign, x.value().mul(y.value()), false, newWidePower(x.PowerOfTen).add(y.PowerOfTen), precision, mode)
+}
+
+// Performs the long division x / y, rounded with the given mode to `precision` significant digits.
+// On a division by zero the result is a zero that must not be stored, see Decimal128.store
+func div128(x, y Decimal128, precision int, mode RoundingMode) (Decimal128, Condition) {
+	sign := x.Sign == y.Sign
+	// Special case: divide by zero
+	if y.IsZero() {
+		if x.IsZero() {
+			// 0 / 0 = ?
+			return Decimal128{Sign: sign}, ConditionInvalidOperation
+		}
+		// 1 / 0 = infinity, which can't be represented
+		return Decimal128{Sign: sign}, ConditionDivisionByZero
+	}
+	// Special case: divide zero
+	if x.IsZero() {
+		// 0 / 1 = 0
+		return Decimal128{Sign: sign}, 0
+	}
+	// Long division, we keep generating digits (18 at a time) until we have
+	// at least one more digit than what can fit in a uint128 or the division is exact
+	divisor := y.value()
+	quotient, remainder := x.value().wide().divmod128(divisor)
+	power := newWidePower(x.PowerOfTen).sub(y.PowerOfTen)
+	for !remainder.isZero() && quotient.cmp(powersOfTen256[len(powersOfTen128)]) < 0 {
+		// Note: since remainder < divisor, the quotient of this step fits in a uint64
+		hi, lo := bits.Mul64(remainder.lo, powersOfTen[18])
+		carry, mid := bits.Mul64(remainder.hi, powersOfTen[18])
+		mid, overflow := bits.Add64(mid, hi, 0)
+		var digits uint64
+		if divisor.hi == 0 {
+			digits, remainder.lo = bits.Div64(mid, lo, divisor.lo)
+		} else {
+			digits, remainder = divmod192(carry+overflow, mid, lo, divisor)
+		}
+		quotient = quotient.mul64(powersOfTen[18]).add(uint256{digits})
+		power = power.sub(18)
+	}
+	return roundCoefficient128(sign, quotient, !remainder.isZero(), power, precision, mode)
+}
+
+// Rounds sign * coefficient * 10^power to a Decimal128 with at most `precision` significant digits
+// (as many as fit in a uint128 if precision <= 0) using the given rounding mode.
+// If sticky is true the coefficient is treated as slightly larger than its value, this is
+// only allowed if the coefficient has more digits than the ones that can be kept.
+// On overflow the result is a zero with ConditionOverflow that must not be stored, see Decimal128.store
+func roundCoefficient128(sign bool, coefficient uint256, sticky bool, power widePower, precision int, mode RoundingMode) (Decimal128, Condition) {
+	// Special case: zero
+	if coefficient.isZero() && !sticky {
+		return Decimal128{Sign: sign}, 0
+	}
+	// Find the largest coefficient allowed and how many digits need to be discarded to get to it
+	limit, limitDigits := uint256{math.MaxUint64, math.MaxUint64}, uint64(len(powersOfTen128))
+	if 0 < precision && precision < len(powersOfTen128) {
+		limit, limitDigits = powersOfTen128[precision].wide().sub(uint256{1}), uint64(precision)
+	}
+	drop := uint64(0)
+	if digits := coefficient.digits(); digits > limitDigits {
+		drop = digits - limitDigits
+	}
+	// The power of ten can't go below math.MinInt64 so we might need to discard even more digits
+	_, under, _ := power.clamp()
+	if drop < under {
+		drop = under
+	}
+	for {
+		quotient, last, rest := coefficient.shiftDigits(drop)
+		rest = rest || sticky
+		half := -1
+		switch {
+		case last > 5 || (last == 5 && rest):
+			half = 1
+		case last == 5:
+			half = 0
+		}
+		inexact := last != 0 || rest
+		if mode.increment(sign, quotient[0]%2 == 1, half, inexact) {
+			quotient = quotient.add(uint256{1})
+		}
+		if quotient.cmp(limit) > 0 {
+			// Too many digits left, try again discarding one more
+			drop++
+			continue
+		}
+		// Prepare the result
+		result := Decimal128{Sign: sign, High: quotient[1], Low: quotient[0]}
+		var cond Condition
+		if inexact {
+			cond |= ConditionInexact
+			if under > 0 {
+				cond |= ConditionUnderflow
+			}
+		}
+		if result.IsZero() {
+			return result, cond
+		}
+		if under > 0 {
+			// Note: drop >= under so this can't overflow
+			result.PowerOfTen = math.MinInt64 + int64(drop-under)
+			return result, cond
+		}
+		if drop > math.MaxInt64 {
+			drop = math.MaxInt64 // Will overflow anyway
+		}
+		var over bool
+		result.PowerOfTen, _, over = power.add(int64(drop)).clamp()
+		if over {
+			return Decimal128{Sign: sign}, cond | ConditionInexact | ConditionOverflow
+		}
+		return result, cond
+	}
+}
+
+// Returns the Value of this number as a uint128
+func (d Decimal128) value() uint128 {
+	return uint128{hi: d.High, lo: d.Low}
+}
+
+// The largest uint128 that can be multiplied by 10 without overflowing
+var maxUint128Div10, _ = uint128{hi: math.MaxUint64, lo: math.MaxUint64}.divmod64(10)
@@ -0,0 +1,280 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+// Parses a Decimal128 or fails the test
+func mustParse128(t testing.TB, numberStr string) decimal.Decimal128 {
+	t.Helper()
+	number, err := decimal.ParseString128(numberStr)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	return number
+}
+
+func TestParseString128(t *testing.T) {
+	testCases := map[string]struct {
+		decimal  decimal.Decimal128
+		hasError bool
+	}{
+		"":                                       {decimal.Decimal128{Sign: true}, false},
+		"0":                                      {decimal.Decimal128{Sign: true}, false},
+		"-0.000":                                 {decimal.Decimal128{Sign: false}, false},
+		".e":                                     {decimal.Decimal128{Sign: true}, false},
+		"0000000000000000000001":                 {decimal.Decimal128{Sign: true, Low: 1}, false},
+		"-1!23.45e-23":                           {decimal.Decimal128{Sign: false, Low: 12345, PowerOfTen: -25}, false},
+		"-1!23.45e-23%":                          {decimal.Decimal128{Sign: false, Low: 12345, PowerOfTen: -27}, false},
+		"12%":                                    {decimal.Decimal128{Sign: true, Low: 12, PowerOfTen: -2}, false},
+		"18446744073709551616":                   {decimal.Decimal128{Sign: true, High: 1, Low: 0}, false},
+		"0.0000000000000000000001":               {decimal.Decimal128{Sign: true, Low: 1, PowerOfTen: -22}, false},
+		"99999999999999999999999999999999999999": {decimal.Decimal128{Sign: true, High: 5421010862427522170, Low: 687399551400673279}, false},
+		"999999999999999999999999999999999999999":  {decimal.Decimal128{Sign: true, High: 5421010862427522170, Low: 687399551400673279, PowerOfTen: 1}, false},
+		"9.99999999999999999999999999999999999999": {decimal.Decimal128{Sign: true, High: 5421010862427522170, Low: 687399551400673279, PowerOfTen: -37}, false},
+		"1e+92233720368547758070":                  {decimal.Decimal128{}, true},
+		"0.01e-9223372036854775807":                {decimal.Decimal128{}, true},
+	}
+	for numberStr, expected := range testCases {
+		actual, err := decimal.ParseString128(numberStr)
+		if (err != nil) != expected.hasError {
+			t.Fatalf("%q returned an unexpected error state: %v", numberStr, err)
+		}
+		if expected.hasError {
+			continue
+		}
+		if actual != expected.decimal {
+			t.Fatalf("%q expected %v, instead got %v", numberStr, expected.decimal, actual)
+		}
+		// UnmarshalText should behave the same
+		unmarshaled := decimal.Decimal128{}
+		if err := unmarshaled.UnmarshalText([]byte(numberStr)); err != nil || unmarshaled != actual {
+			t.Fatalf("%q UnmarshalText returned (%v, %v), expected %v", numberStr, unmarshaled, err, actual)
+		}
+	}
+	var nilDecimal *decimal.Decimal128 = nil
+	if err := nilDecimal.UnmarshalText([]byte("")); err != decimal.ErrorNilPointer {
+		t.Fatalf("Unexpected error (or lack there of) from [nil].UnmarshalText: %v", err)
+	}
+}
+
+func TestDecimal128Format(t *testing.T) {
+	testCases := map[string]string{
+		"":          "0",
+		"-0.000123": "-0.000123",
+		"12345678901234567890.123456789012345678": "12345678901234567890.123456789012345678",
+		"340282366920938463463374607431768211455": "340282366920938463463374607431768211455",
+		"1.5e30": "1500000000000000000000000000000",
+	}
+	for numberStr, expected := range testCases {
+		if actual := mustParse128(t, numberStr).FormatText(false, false, 0); actual != expected {
+			t.Fatalf("Expected %q for %q, instead got %q", expected, numberStr, actual)
+		}
+	}
+	if actual := mustParse128(t, "0.12345678901234567890123").FormatText(true, true, 4); actual != "1234e-2%" {
+		t.Fatalf("Expected \"1234e-2%%\", instead got %q", actual)
+	}
+}
+
+func TestDecimal128Aritmetic(t *testing.T) {
+	testCases := map[struct {
+		x, op, y string
+	}]struct {
+		result string
+		ok     bool
+	}{
+		{"0", "+", "0"}:  {"0", true},
+		{"1", "-", "1"}:  {"0", true},
+		{"1", "*", "0"}:  {"0", true},
+		{"0", "/", "3"}:  {"0", true},
+		{"1", "/", "0"}:  {"7", false},
+		{"0", "/", "0"}:  {"7", false},
+		{"1", "/", "3"}:  {"333333333333333333333333333333333333333e-39", true},
+		{"2", "/", "3"}:  {"66666666666666666666666666666666666667e-38", true},
+		{"-1", "/", "7"}: {"-142857142857142857142857142857142857143e-39", true},
+		{"123456789012345678901234567890.123456789", "*", "987654321098765432109876543210.987654321"}: {"121932631137021795226185032733866788594e21", true},
+		{"340282366920938463463374607431768211455", "+", "1"}:                                         {"34028236692093846346337460743176821146e1", true},
+		{"340282366920938463463374607431768211455", "+", "5"}:                                         {"34028236692093846346337460743176821146e1", true},
+		{"1e40", "-", "1e-10"}:                               {"1e40", true},
+		{"99999999999999999999999999999999999999", "+", "1"}: {"1e38", true},
+		{"1", "-", "1e-38"}:                                  {"99999999999999999999999999999999999999e-38", true},
+		{"1", "-", "1e-39"}:                                  {"1", true},
+		{"340282366920938463463374607431768211455", "/", "18446744073709551616"}:          {"184467440737095516159999999999999999999e-19", true},
+		{"1e38", "/", "340282366920938463463374607431768211455"}:                          {"293873587705571876992184134305561419456e-39", true},
+		{"123456789012345678901234567890123456789", "/", "98765432109876543210987654321"}: {"124999998860937500014238281249947021483e-29", true},
+		{"1e9223372036854775807", "*", "1e9223372036854775807"}:                           {"7", false},
+		{"1e-9223372036854775807", "*", "0.001"}:                                          {"0", false},
+	}
+	for test, expected := range testCases {
+		x, y := mustParse128(t, test.x), mustParse128(t, test.y)
+		// Failed operations leave the result unchanged
+		result := mustParse128(t, "7")
+		var ok bool
+		switch test.op {
+		case "+":
+			ok = result.Add(x, y)
+		case "-":
+			ok = result.Sub(x, y)
+		case "*":
+			ok = result.Mult(x, y)
+		case "/":
+			ok = result.Div(x, y)
+		}
+		if ok != expected.ok || !result.Equals(mustParse128(t, expected.result)) {
+			t.Fatalf("%s %s %s expected (%s, %v), instead got (%s, %v)", test.x, test.op, test.y,
+				expected.result, expected.ok, result.FormatText(true, false, 0), ok)
+		}
+	}
+	// DivRound
+	result := decimal.Decimal128{}
+	if !result.DivRound(mustParse128(t, "2"), mustParse128(t, "3"), 34, decimal.RoundDown) ||
+		!result.Equals(mustParse128(t, "0.6666666666666666666666666666666666")) {
+		t.Fatalf("Expected 2/3 with 34 digits to be 0.666..., instead got %s", result.FormatText(false, false, 0))
+	}
+	// Make sure we handle nil
+	var nilDecimal *decimal.Decimal128 = nil
+	if nilDecimal.Add(decimal.Decimal128{}, decimal.Decimal128{}) ||
+		nilDecimal.Sub(decimal.Decimal128{}, decimal.Decimal128{}) ||
+		nilDecimal.Mult(decimal.Decimal128{}, decimal.Decimal128{}) ||
+		nilDecimal.Div(decimal.Decimal128{}, decimal.Decimal128{}) {
+		t.Fatal("Expect aritmetic ops to return false if run on a nil decimal")
+	}
+}
+
+func TestDecimal128Utils(t *testing.T) {
+	number := mustParse128(t, "-12300")
+	if !number.IsNegative() || number.IsPositive() || number.IsZero() {
+		t.Fatalf("%v should be negative", number)
+	}
+	clone := number.Clone()
+	clone.Abs()
+	if !clone.IsPositive() || !number.IsNegative() {
+		t.Fatalf("Abs() on a clone should not affect the original: %v, %v", clone, number)
+	}
+	clone.Compress()
+	if clone != (decimal.Decimal128{Sign: true, Low: 123, PowerOfTen: 2}) {
+		t.Fatalf("Expected compress to return 123e2, instead got %v", clone)
+	}
+	clone.Expand()
+	if clone != (decimal.Decimal128{Sign: true, High: 6667843360785852269, Low: 2690175855593783296, PowerOfTen: -34}) {
+		t.Fatalf("Expected expand to return 123e36*10^-34, instead got %v", clone)
+	}
+	if !clone.Equals(mustParse128(t, "12300")) {
+		t.Fatal("Expand and Compress changed the value of the number")
+	}
+	tiny := decimal.Decimal128{Sign: true, Low: 5, PowerOfTen: math.MinInt64 + 2}
+	tiny.Expand()
+	if tiny != (decimal.Decimal128{Sign: true, Low: 500, PowerOfTen: math.MinInt64}) {
+		t.Fatalf("Expected expand to stop at the smallest power of ten, instead got %v", tiny)
+	}
+	clone.Zero()
+	if !clone.IsZero() || clone.IsPositive() || clone.IsNegative() {
+		t.Fatalf("Failed to Zero() decimal: got %v", clone)
+	}
+	// Can Abs(), Zero(), Compress() and Expand() handle nil without panic?
+	var nilDecimal *decimal.Decimal128 = nil
+	nilDecimal.Abs()
+	nilDecimal.Zero()
+	nilDecimal.Compress()
+	nilDecimal.Expand()
+}
+
+func TestDecimal128Conversion(t *testing.T) {
+	testCases := map[string]struct {
+		decimal decimal.Decimal
+		ok      bool
+	}{
+		"0":                       {decimal.Decimal{Sign: true}, true},
+		"-123.45":                 {decimal.Decimal{Sign: false, Value: 12345, PowerOfTen: -2}, true},
+		"18446744073709551615":    {decimal.Decimal{Sign: true, Value: math.MaxUint64}, true},
+		"18446744073709551615000": {decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: 3}, true},
+		"18446744073709551616":    {decimal.Decimal{Sign: true, Value: 1844674407370955162, PowerOfTen: 1}, false},
+		"1.0000000000000000000000000000000000001":     {decimal.Decimal{Sign: true, Value: 1}, false},
+		"12345678901234567890123e9223372036854775805": {decimal.Inf(true), false},
+	}
+	for numberStr, expected := range testCases {
+		number := mustParse128(t, numberStr)
+		actual, ok := number.Decimal()
+		if !actual.Equals(expected.decimal) || ok != expected.ok {
+			t.Fatalf("%q expected to convert to (%v, %v), instead got (%v, %v)", numberStr, expected.decimal, expected.ok, actual, ok)
+		}
+		if back, converted := decimal.Decimal128FromDecimal(actual); ok && (!converted || !back.Equals(number)) {
+			t.Fatalf("%q didn't convert back to the same Decimal128", numberStr)
+		}
+	}
+	// NaN and infinities can't be converted
+	for _, special := range []decimal.Decimal{decimal.NaN(), decimal.Inf(true), decimal.Inf(false)} {
+		if result, ok := decimal.Decimal128FromDecimal(special); ok || !result.IsZero() {
+			t.Fatalf("Expected %v to convert to zero and false, instead got (%v, %v)", special, result, ok)
+		}
+	}
+}
+
+func BenchmarkDecimal128(b *testing.B) {
+	x := mustParse128(b, "123456789012345678901234567890.12345678")
+	y := mustParse128(b, "-987654321.876543210987654322")
+	result := decimal.Decimal128{}
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Add(x, y)
+		}
+	})
+	b.Run("Mult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Mult(x, y)
+		}
+	})
+	b.Run("Div", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.Div(x, y)
+		}
+	})
+}
+
+func FuzzDecimal128(f *testing.F) {
+	seeds := []struct {
+		x, y decimal.Decimal128
+	}{
+		{decimal.Decimal128{}, decimal.Decimal128{}},
+		{decimal.Decimal128{Sign: true, Low: 1}, decimal.Decimal128{Sign: true, Low: 3}},
+		{decimal.Decimal128{Sign: false, High: math.MaxUint64, Low: 15, PowerOfTen: -1}, decimal.Decimal128{Sign: true, Low: 2}},
+		{decimal.Decimal128{Sign: true, High: 1, PowerOfTen: -40}, decimal.Decimal128{Sign: true, High: math.MaxUint64, Low: math.MaxUint64, PowerOfTen: -21}},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.x.Sign, seed.x.High, seed.x.Low, int16(seed.x.PowerOfTen), seed.y.Sign, seed.y.High, seed.y.Low, int16(seed.y.PowerOfTen))
+	}
+	f.Fuzz(func(t *testing.T, xSign bool, xHigh, xLow uint64, xPower int16, ySign bool, yHigh, yLow uint64, yPower int16) {
+		// NOTE: We limit the power of ten to keep the reference big.Rat reasonably small
+		x := decimal.Decimal128{Sign: xSign, High: xHigh, Low: xLow, PowerOfTen: int64(xPower)}
+		y := decimal.Decimal128{Sign: ySign, High: yHigh, Low: yLow, PowerOfTen: int64(yPower)}
+		// The result is correctly rounded if it's within half a unit of the last digit from the exact result
+		check := func(op string, result decimal.Decimal128, ok bool, expected *big.Rat) {
+			if !ok {
+				t.Fatalf("%v %s %v failed", x, op, y)
+			}
+			result.Expand()
+			if result.IsZero() {
+				result.PowerOfTen = math.MinInt16 * 3
+			}
+			halfUnit := toRat128(decimal.Decimal128{Sign: true, Low: 5, PowerOfTen: result.PowerOfTen - 1})
+			if difference := new(big.Rat).Sub(toRat128(result), expected); difference.Abs(difference).Cmp(halfUnit) > 0 {
+				t.Fatalf("%v %s %v returned %v, expected %s", x, op, y, result, expected.FloatString(50))
+			}
+		}
+		result := decimal.Decimal128{}
+		ok := result.Add(x, y)
+		check("+", result, ok, new(big.Rat).Add(toRat128(x), toRat128(y)))
+		ok = result.Sub(x, y)
+		check("-", result, ok, new(big.Rat).Sub(toRat128(x), toRat128(y)))
+		ok = result.Mult(x, y)
+		check("*", result, ok, new(big.Rat).Mul(toRat128(x), toRat128(y)))
+		if !y.IsZero() {
+			ok = result.Div(x, y)
+			check("/", result, ok, new(big.Rat).Quo(toRat128(x), toRat128(y)))
+		}
+	})
+}
@@ -0,0 +1,481 @@
+package decimal
+
+import (
+	"math/big"
+	"sync"
+)
+
+// Returns e^x rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow
+//
+// Example:
+//
+//	// 1000 with continuous compounding at 5% for 10 years
+//	growth, err := decimal.Exp(decimal.Decimal{Sign: true, Value: 5, PowerOfTen: -1}, 0)
+//	total, err := decimal.Mult(decimal.DecimalFromInt(1000), growth)
+func Exp(x Decimal, precision int) (Decimal, error) {
+	result, cond := exp(x, Context{Precision: precision})
+	return result, conditionError(cond)
+}
+
+// Returns the natural logarithm of x rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal.
+// Returns -infinity if x is zero, or NaN and ErrIndeterminate if x is negative
+func Ln(x Decimal, precision int) (Decimal, error) {
+	result, cond := ln(x, Context{Precision: precision})
+	return result, conditionError(cond)
+}
+
+// Returns the base 10 logarithm of x rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal.
+// Returns -infinity if x is zero, or NaN and ErrIndeterminate if x is negative
+func Log10(x Decimal, precision int) (Decimal, error) {
+	result, cond := log10(x, Context{Precision: precision})
+	return result, conditionError(cond)
+}
+
+// Returns the logarithm of x in the given base rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal.
+// Returns ±infinity if x is zero, or NaN and ErrIndeterminate if x is negative or if
+// the base is not a finite positive number other than 1
+func Log(x, base Decimal, precision int) (Decimal, error) {
+	result, cond := log(x, base, Context{Precision: precision})
+	return result, conditionError(cond)
+}
+
+// Compute e^x and store the result in this decimal, rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 (or above MaxBigDigits) means MaxBigDigits.
+// If the decimal is nil, this operation will be a noop.
+// If the operation overflows/underflows, will return false
+// If the operation overflows, the decimal is left unchanged
+func (d *BigDecimal) Exp(x BigDecimal, precision int) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(expBig(x, precision))&^ConditionInexact == 0
+}
+
+// Compute the natural logarithm of x and store the result in this decimal,
+// rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 (or above MaxBigDigits) means MaxBigDigits.
+// If the decimal is nil, this operation will be a noop.
+// If x is not positive, the decimal is left unchanged and will return false
+func (d *BigDecimal) Ln(x BigDecimal, precision int) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(logBig(x, nil, precision))&^ConditionInexact == 0
+}
+
+// Compute the base 10 logarithm of x and store the result in this decimal,
+// rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 (or above MaxBigDigits) means MaxBigDigits.
+// If the decimal is nil, this operation will be a noop.
+// If x is not positive, the decimal is left unchanged and will return false
+func (d *BigDecimal) Log10(x BigDecimal, precision int) (ok bool) {
+	return d.Log(x, BigDecimal{Sign: true, Value: big.NewInt(1), PowerOfTen: 1}, precision)
+}
+
+// Compute the logarithm of x in the given base and store the result in this decimal,
+// rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 (or above MaxBigDigits) means MaxBigDigits.
+// If the decimal is nil, this operation will be a noop.
+// If x is not positive or the base is not a positive number other than 1, the decimal is left unchanged and will return false
+func (d *BigDecimal) Log(x, base BigDecimal, precision int) (ok bool) {
+	// Special case: nil
+	if d == nil {
+		return false // NOOP
+	}
+	return d.store(logBig(x, &base, precision))&^ConditionInexact == 0
+}
+
+// Computes e^x, rounded according to the given context
+func exp(x Decimal, ctx Context) (Decimal, Condition) {
+	// Special cases: NaN, infinities and zero
+	switch {
+	case x.IsNaN():
+		return NaN(), 0
+	case x.IsInf() && x.Sign:
+		return Inf(true), 0
+	case x.IsInf():
+		return Decimal{Sign: true}, 0
+	case x.IsZero():
+		return Decimal{Sign: true, Value: 1}, 0
+	}
+	coefficient, power := expApproximation(x.big(), resultDigits(ctx))
+	return roundBigCoefficient(true, coefficient, true, power, ctx)
+}
+
+// Computes the natural logarithm of x, rounded according to the given context
+func ln(x Decimal, ctx Context) (Decimal, Condition) {
+	if result, cond, done := logSpecialCases(x, true); done {
+		return result, cond
+	}
+	coefficient, power := approximateResult(resultDigits(ctx), func(digits uint64) (*big.Int, widePower) {
+		return lnApproximation(x.big(), digits)
+	})
+	return roundBigCoefficient(coefficient.Sign() >= 0, coefficient.Abs(coefficient), true, power, ctx)
+}
+
+// Computes the base 10 logarithm of x, rounded according to the given context
+func log10(x Decimal, ctx Context) (Decimal, Condition) {
+	if result, cond, done := logSpecialCases(x, true); done {
+		return result, cond
+	}
+	// Special case: powers of ten
+	if x.Compress(); x.Value == 1 {
+		return roundCoefficient(x.PowerOfTen >= 0, uint128{lo: absInt64(x.PowerOfTen)}, false, widePower{}, ctx)
+	}
+	coefficient, power := approximateResult(resultDigits(ctx), func(digits uint64) (*big.Int, widePower) {
+		return log10Approximation(x.big(), digits)
+	})
+	return roundBigCoefficient(coefficient.Sign() >= 0, coefficient.Abs(coefficient), true, power, ctx)
+}
+
+// Computes the logarithm of x in the given base, rounded according to the given context
+func log(x, base Decimal, ctx Context) (Decimal, Condition) {
+	one := Decimal{Sign: true, Value: 1}
+	switch {
+	case x.IsNaN() || base.IsNaN():
+		return NaN(), 0
+	case !base.IsFinite() || !base.IsPositive() || base.Equals(one):
+		return NaN(), ConditionInvalidOperation
+	}
+	if result, cond, done := logSpecialCases(x, base.Greater(one)); done {
+		return result, cond
+	}
+	if exact, ok := exactLog(x.big(), base.big()); ok {
+		return roundBigCoefficient(exact.Sign, exact.Value, false, newWidePower(exact.PowerOfTen), ctx)
+	}
+	coefficient, power := approximateResult(resultDigits(ctx), func(digits uint64) (*big.Int, widePower) {
+		return logApproximation(x.big(), base.big(), digits)
+	})
+	return roundBigCoefficient(coefficient.Sign() >= 0, coefficient.Abs(coefficient), true, power, ctx)
+}
+
+// Returns the logarithm of x in a base above one (or below one if increasing is false) if it doesn't
+// need to be computed: for NaN, infinities, zero, negative numbers and one.
+// Returns false if x is none of those
+func logSpecialCases(x Decimal, increasing bool) (Decimal, Condition, bool) {
+	switch {
+	case x.IsNaN():
+		return NaN(), 0, true
+	case x.IsZero():
+		return Inf(!increasing), 0, true
+	case !x.Sign:
+		return NaN(), ConditionInvalidOperation, true
+	case x.IsInf():
+		return Inf(increasing), 0, true
+	case x.Equals(Decimal{Sign: true, Value: 1}):
+		return Decimal{Sign: true}, 0, true
+	}
+	return Decimal{}, 0, false
+}
+
+// Computes e^x rounded with RoundHalfEven to `precision` significant digits
+func expBig(x BigDecimal, precision int) (BigDecimal, Condition) {
+	if precision <= 0 || precision > MaxBigDigits {
+		precision = MaxBigDigits
+	}
+	// Special case: zero
+	if x.IsZero() {
+		return BigDecimal{Sign: true, Value: big.NewInt(1)}, 0
+	}
+	coefficient, power := expApproximation(x, []uint64{uint64(precision)})
+	return roundBig(true, coefficient, true, power, uint64(precision), RoundHalfEven)
+}
+
+// Computes the logarithm of x in the given base (or the natural logarithm if base is nil)
+// rounded with RoundHalfEven to `precision` significant digits
+func logBig(x BigDecimal, base *BigDecimal, precision int) (BigDecimal, Condition) {
+	if precision <= 0 || precision > MaxBigDigits {
+		precision = MaxBigDigits
+	}
+	one := BigDecimal{Sign: true, Value: big.NewInt(1)}
+	// Special cases: invalid operands and one
+	switch {
+	case x.IsZero():
+		// ln(0) = -∞
+		return BigDecimal{Sign: true, Value: new(big.Int)}, ConditionDivisionByZero
+	case !x.Sign || (base != nil && (!base.IsPositive() || base.Equals(one))):
+		return BigDecimal{Sign: true, Value: new(big.Int)}, ConditionInvalidOperation
+	case x.Equals(one):
+		return BigDecimal{Sign: true, Value: new(big.Int)}, 0
+	}
+	approximate := func(digits uint64) (*big.Int, widePower) {
+		return lnApproximation(x, digits)
+	}
+	if base != nil {
+		if exact, ok := exactLog(x, *base); ok {
+			return roundBig(exact.Sign, exact.Value, false, newWidePower(exact.PowerOfTen), uint64(precision), RoundHalfEven)
+		}
+		approximate = func(digits uint64) (*big.Int, widePower) {
+			return logApproximation(x, *base, digits)
+		}
+	}
+	coefficient, power := approximateResult([]uint64{uint64(precision)}, approximate)
+	return roundBig(coefficient.Sign() >= 0, coefficient.Abs(coefficient), true, power, uint64(precision), RoundHalfEven)
+}
+
+// Returns an approximation of a result (that is never exact) with enough digits to round it correctly to
+// any of the `kept` numbers of significant digits. The approximation is computed with more and more digits,
+// until it's far enough from the values where rounding changes.
+// `approximate` must return at least the given number of significant digits, that differ from the exact result
+// by less than 100 units in the last place
+func approximateResult(kept []uint64, approximate func(digits uint64) (*big.Int, widePower)) (*big.Int, widePower) {
+	margin := big.NewInt(100)
+	for guard := uint64(10); ; guard *= 2 {
+		coefficient, power := approximate(kept[len(kept)-1] + guard)
+		if guard > maxGuardDigits || !ambiguousRounding(new(big.Int).Abs(coefficient), margin, kept) {
+			return coefficient, power
+		}
+	}
+}
+
+// Returns an approximation of e^x, for a non-zero x, that can be rounded to any of the `kept` numbers of digits.
+// Since e^x is never exact, the coefficient must be rounded as if it was slightly larger
+func expApproximation(x BigDecimal, kept []uint64) (*big.Int, widePower) {
+	// e^x overflows/underflows any representation for |x| >= 10^20
+	adjusted := newWidePower(x.PowerOfTen).add(int64(bigDigits(x.Value)) - 1)
+	if !adjusted.less(newWidePower(20)) {
+		if x.Sign {
+			return big.NewInt(1), widePower{hi: 1}
+		}
+		return big.NewInt(1), widePower{hi: -2}
+	}
+	// For |x| < 10^-(digits+1), e^x is between 1 - 10^-digits and 1 + 10^-digits
+	if digits := kept[len(kept)-1] + 3; adjusted.less(newWidePower(-int64(digits) - 1)) {
+		one := bigPowerOfTen(digits)
+		if !x.Sign {
+			one.Sub(one, bigOne)
+		}
+		return one, newWidePower(-int64(digits))
+	}
+	return approximateResult(kept, func(digits uint64) (*big.Int, widePower) {
+		// e^x = e^r * 10^k with r = x - k * ln(10) in [0, ln(10))
+		// Note: k has at most 20 digits, so 22 more digits of ln(10) are enough
+		scale := digits + 1
+		extra := scale + 22
+		k, r := new(big.Int).DivMod(toFixed(x, extra), lnTenFixed(extra), new(big.Int))
+		r.Quo(r, bigPowerOfTen(extra-scale))
+		return expFixed(r, scale), bigWidePower(k).sub(int64(scale))
+	})
+}
+
+// Returns ln(x), for a positive x other than one, with at least `digits` significant digits
+func lnApproximation(x BigDecimal, digits uint64) (*big.Int, widePower) {
+	// ln(x) = ln(m) + e * ln(10) with m = x / 10^e in [1, 10)
+	mantissaDigits := bigDigits(x.Value)
+	e := new(big.Int).Add(big.NewInt(x.PowerOfTen), new(big.Int).SetUint64(mantissaDigits-1))
+	for scale := digits + 2; ; {
+		result := lnFixed(toFixed(BigDecimal{Sign: true, Value: x.Value, PowerOfTen: 1 - int64(mantissaDigits)}, scale), scale)
+		lnTen := lnTenFixed(scale + 20)
+		result.Add(result, lnTen.Mul(lnTen, e).Quo(lnTen, bigPowerOfTen(20)))
+		// For x close to one the result is small, so more digits after the decimal point are needed
+		if found := bigDigits(result); found < digits {
+			scale += digits - found + 1
+			continue
+		}
+		return result, newWidePower(-int64(scale))
+	}
+}
+
+// Returns log10(x), for a positive x that is not a power of ten, with at least `digits` significant digits
+func log10Approximation(x BigDecimal, digits uint64) (*big.Int, widePower) {
+	// log10(x) = ln(m) / ln(10) + e with m = x / 10^e in [1, 10)
+	mantissaDigits := bigDigits(x.Value)
+	e := new(big.Int).Add(big.NewInt(x.PowerOfTen), new(big.Int).SetUint64(mantissaDigits-1))
+	for scale := digits + 2; ; {
+		result := lnFixed(toFixed(BigDecimal{Sign: true, Value: x.Value, PowerOfTen: 1 - int64(mantissaDigits)}, scale+5), scale+5)
+		result.Mul(result, bigPowerOfTen(scale)).Quo(result, lnTenFixed(scale+5))
+		result.Add(result, new(big.Int).Mul(e, bigPowerOfTen(scale)))
+		// For x close to one the result is small, so more digits after the decimal point are needed
+		if found := bigDigits(result); found < digits {
+			scale += digits - found + 1
+			continue
+		}
+		return result, newWidePower(-int64(scale))
+	}
+}
+
+// Returns the logarithm of x in the given base, for a positive x other than one and a positive base
+// other than one, with at least `digits` significant digits
+func logApproximation(x, base BigDecimal, digits uint64) (*big.Int, widePower) {
+	lnX, powerX := lnApproximation(x, digits+6)
+	lnBase, powerBase := lnApproximation(base, digits+6)
+	// Scale the logarithms so that the quotient has a couple more digits than needed
+	shift := int64(digits+2) + int64(bigDigits(lnBase)) - int64(bigDigits(lnX))
+	if shift >= 0 {
+		lnX.Mul(lnX, bigPowerOfTen(uint64(shift)))
+	} else {
+		lnBase.Mul(lnBase, bigPowerOfTen(uint64(-shift)))
+		shift = 0
+	}
+	return lnX.Quo(lnX, lnBase), powerX.subWide(powerBase).sub(shift)
+}
+
+// The denominators of the fractions checked by exactLog, alongside the power of ten they divide
+var exactLogDenominators = [...]struct{ denominator, power uint64 }{{1, 0}, {2, 1}, {4, 2}, {5, 1}, {8, 3}, {10, 1}}
+
+// Returns the logarithm of x in the given base if it's a fraction with a small denominator
+// and a finite decimal expansion (for example log4(8) = 1.5), alongside true if found
+func exactLog(x, base BigDecimal) (BigDecimal, bool) {
+	// Estimate the logarithm, and give up on large results
+	estimate, power := logApproximation(x, base, 20)
+	if power.hi != -1 || power.lo < 1<<63 || bigDigits(estimate) > uint64(-int64(power.lo))+6 {
+		return BigDecimal{}, false
+	}
+	estimateRat := new(big.Rat).SetFrac(estimate, bigPowerOfTen(uint64(-int64(power.lo))))
+	x.Compress()
+	base.Compress()
+	for _, fraction := range exactLogDenominators {
+		// x = base^(a/q) if x^q = base^a, that is if xValue^q / baseValue^a = 10^(basePower*a - xPower*q)
+		q := new(big.Int).SetUint64(fraction.denominator)
+		a := new(big.Rat).Mul(estimateRat, new(big.Rat).SetInt(q))
+		a.Add(a, big.NewRat(int64(a.Sign()), 2))
+		rounded := new(big.Int).Quo(a.Num(), a.Denom())
+		if !rounded.IsInt64() || bigDigits(x.Value)*fraction.denominator > 100000 ||
+			bigDigits(base.Value)*absInt64(rounded.Int64()) > 100000 {
+			continue
+		}
+		lhs := new(big.Rat).SetInt(new(big.Int).Exp(x.Value, q, nil))
+		baseTerm := new(big.Rat).SetInt(new(big.Int).Exp(base.Value, new(big.Int).Abs(rounded), nil))
+		if rounded.Sign() > 0 {
+			lhs.Quo(lhs, baseTerm)
+		} else {
+			lhs.Mul(lhs, baseTerm)
+		}
+		k := new(big.Int).Mul(big.NewInt(base.PowerOfTen), rounded)
+		k.Sub(k, new(big.Int).Mul(big.NewInt(x.PowerOfTen), q))
+		if !k.IsInt64() || absInt64(k.Int64()) > 100000 {
+			continue
+		}
+		rhs := new(big.Rat).SetInt(bigPowerOfTen(absInt64(k.Int64())))
+		if k.Sign() < 0 {
+			rhs.Inv(rhs)
+		}
+		if lhs.Cmp(rhs) == 0 {
+			// a/q = a * (10^power / q) / 10^power
+			value := new(big.Int).Mul(rounded, new(big.Int).Quo(bigPowerOfTen(fraction.power), q))
+			return BigDecimal{Sign: value.Sign() >= 0, Value: value.Abs(value), PowerOfTen: -int64(fraction.power)}, true
+		}
+	}
+	return BigDecimal{}, false
+}
+
+// Returns x * 10^scale truncated to an integer, x must be smaller than 10^20.
+// Note: the series are computed on fixed point integers rather than with Add, Mult and Div,
+// since those round every intermediate result to the digits of a Decimal and the error of a series
+// would then be too large to round the result correctly
+func toFixed(x BigDecimal, scale uint64) *big.Int {
+	value := x.value()
+	if shift := newWidePower(x.PowerOfTen).add(int64(scale)); shift.hi >= 0 {
+		value.Mul(value, bigPowerOfTen(shift.lo))
+	} else if drop := (widePower{}).subWide(shift); drop.hi != 0 || drop.lo > bigDigits(value) {
+		value.SetInt64(0)
+	} else {
+		value.Quo(value, bigPowerOfTen(drop.lo))
+	}
+	if !x.Sign {
+		value.Neg(value)
+	}
+	return value
+}
+
+// Returns e^r for a fixed point number r with `scale` digits after the decimal point, in [0, ln(10))
+func expFixed(r *big.Int, scale uint64) *big.Int {
+	// e^r = (e^(r / 2^halvings))^(2^halvings), which converges much faster.
+	// Squaring doubles the error every time, so we need about halvings / 3 more digits
+	const halvings, guard = 20, 20
+	one := bigPowerOfTen(scale + guard)
+	reduced := new(big.Int).Mul(r, bigPowerOfTen(guard))
+	reduced.Rsh(reduced, halvings)
+	// Taylor series: 1 + r + r^2/2! + r^3/3! + ...
+	sum, term := new(big.Int).Set(one), new(big.Int).Set(one)
+	for k := int64(1); term.Sign() != 0; k++ {
+		term.Mul(term, reduced).Quo(term, one).Quo(term, big.NewInt(k))
+		sum.Add(sum, term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum).Quo(sum, one)
+	}
+	return sum.Quo(sum, bigPowerOfTen(guard))
+}
+
+// Returns ln(m) for a fixed point number m with `scale` digits after the decimal point, in [1, 10)
+func lnFixed(m *big.Int, scale uint64) *big.Int {
+	// ln(m) = 2^roots * ln(m^(1 / 2^roots)), which converges much faster.
+	// Multiplying doubles the error every time, so we need about roots / 3 more digits
+	const roots, guard = 10, 20
+	one := bigPowerOfTen(scale + guard)
+	reduced := new(big.Int).Mul(m, bigPowerOfTen(guard))
+	for i := 0; i < roots; i++ {
+		reduced.Sqrt(reduced.Mul(reduced, one))
+	}
+	// ln(y) = 2 * atanh((y - 1) / (y + 1))
+	z := new(big.Int).Sub(reduced, one)
+	z.Mul(z, one).Quo(z, reduced.Add(reduced, one))
+	sum := atanhFixed(z, one)
+	sum.Lsh(sum, roots+1)
+	return sum.Quo(sum, bigPowerOfTen(guard))
+}
+
+// Returns atanh(z) = z + z^3/3 + z^5/5 + ... for a fixed point number z / one in [0, 1)
+func atanhFixed(z, one *big.Int) *big.Int {
+	sum, power := new(big.Int).Set(z), new(big.Int).Set(z)
+	square := new(big.Int).Mul(z, z)
+	square.Quo(square, one)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, square).Quo(power, one)
+		term := new(big.Int).Quo(power, big.NewInt(k))
+		if term.Sign() == 0 {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// The most precise value of ln(10) computed so far, as a fixed point number with `scale` digits
+var lnTen struct {
+	sync.Mutex
+	scale uint64
+	value *big.Int
+}
+
+// Returns ln(10) as a fixed point number with `scale` digits after the decimal point
+func lnTenFixed(scale uint64) *big.Int {
+	lnTen.Lock()
+	defer lnTen.Unlock()
+	if lnTen.value == nil || lnTen.scale < scale {
+		// ln(10) = 3 * ln(2) + ln(5/4) = 6 * atanh(1/3) + 2 * atanh(1/9)
+		const guard = 10
+		one := bigPowerOfTen(scale + guard)
+		value := atanhFixed(new(big.Int).Quo(one, big.NewInt(3)), one)
+		value.Mul(value, big.NewInt(6))
+		value.Add(value, new(big.Int).Lsh(atanhFixed(new(big.Int).Quo(one, big.NewInt(9)), one), 1))
+		lnTen.scale, lnTen.value = scale, value.Quo(value, bigPowerOfTen(guard))
+	}
+	return new(big.Int).Quo(lnTen.value, bigPowerOfTen(lnTen.scale-scale))
+}
+
+// Returns v as a widePower, saturating way past the int64 range if it doesn't fit
+func bigWidePower(v *big.Int) widePower {
+	switch {
+	case v.IsInt64():
+		return newWidePower(v.Int64())
+	case v.Sign() > 0:
+		return widePower{hi: 1}
+	}
+	return widePower{hi: -2}
+}
+
+// Returns the absolute value of x
+func absInt64(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
+}
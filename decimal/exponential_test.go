@@ -0,0 +1,291 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestExp(t *testing.T) {
+	testCases := map[struct {
+		x         string
+		precision int
+	}]struct {
+		result string
+		err    error
+	}{
+		{"1", 0}:       {"2.718281828459045235", nil},
+		{"1", 5}:       {"2.7183", nil},
+		{"-1", 0}:      {"0.3678794411714423216", nil},
+		{"2", 0}:       {"7.389056098930650227", nil},
+		{"0.5", 0}:     {"1.6487212707001281468", nil},
+		{"10", 0}:      {"22026.46579480671652", nil},
+		{"-10", 0}:     {"0.00004539992976248485154", nil},
+		{"100", 0}:     {"2.688117141816135448e43", nil},
+		{"0.001", 0}:   {"1.0010005001667083417", nil},
+		{"123.456", 0}: {"4.132944352778093450e53", nil},
+		{"-50", 0}:     {"1.928749847963917783e-22", nil},
+		{"1e-30", 0}:   {"1", nil},
+		{"-1e-30", 0}:  {"1", nil},
+		{"0", 0}:       {"1", nil},
+		{"-0", 0}:      {"1", nil},
+		{"Inf", 0}:     {"Inf", nil},
+		{"-Inf", 0}:    {"0", nil},
+		{"NaN", 0}:     {"NaN", nil},
+		{"1e20", 0}:    {"Inf", decimal.ErrOverflow},
+		{"-1e20", 0}:   {"0", decimal.ErrUnderflow},
+		{"1e19", 0}:    {"3.245556613994135087e4342944819032518276", nil},
+		{"-2.2e19", 0}: {"0", decimal.ErrUnderflow},
+		{"2.2e19", 0}:  {"Inf", decimal.ErrOverflow},
+		{"-1e-5", 10}:  {"0.9999900000", nil},
+		{"1e-5", 0}:    {"1.0000100000500001667", nil},
+		{"-100", 0}:    {"3.720075976020835963e-44", nil},
+	}
+	for test, expected := range testCases {
+		result, err := decimal.Exp(mustParse(t, test.x), test.precision)
+		if !result.Equals(mustParse(t, expected.result)) || !errors.Is(err, expected.err) {
+			t.Fatalf("e^%s with precision %d expected (%s, %v), instead got (%v, %v)",
+				test.x, test.precision, expected.result, expected.err, result, err)
+		}
+	}
+}
+
+func TestLog(t *testing.T) {
+	testCases := map[struct {
+		x, base   string
+		precision int
+	}]struct {
+		result string
+		err    error
+	}{
+		{"2", "e", 0}:             {"0.6931471805599453094", nil},
+		{"2", "e", 3}:             {"0.693", nil},
+		{"10", "e", 0}:            {"2.302585092994045684", nil},
+		{"0.5", "e", 0}:           {"-0.6931471805599453094", nil},
+		{"1.0000000001", "e", 0}:  {"9.9999999995e-11", nil},
+		{"123456789", "e", 0}:     {"18.63140176616801803", nil},
+		{"1e-100", "e", 0}:        {"-230.2585092994045684", nil},
+		{"1e300", "e", 0}:         {"690.7755278982137052", nil},
+		{"3.7", "e", 0}:           {"1.3083328196501787604", nil},
+		{"2", "10", 0}:            {"0.3010299956639811952", nil},
+		{"0.5", "10", 0}:          {"-0.3010299956639811952", nil},
+		{"1.0000000001", "10", 0}: {"4.342944818815371036e-11", nil},
+		{"123456789", "10", 0}:    {"8.091514977169270448", nil},
+		{"3.7", "10", 0}:          {"0.5682017240669949968", nil},
+		{"1e-100", "10", 0}:       {"-100", nil},
+		{"1000", "10", 2}:         {"3", nil},
+		{"10", "2", 0}:            {"3.321928094887362348", nil},
+		{"3", "7", 0}:             {"0.5645750340535796138", nil},
+		{"100", "0.1", 0}:         {"-2", nil},
+		{"8", "4", 0}:             {"1.5", nil},
+		{"0.125", "2", 0}:         {"-3", nil},
+		{"2", "0.5", 0}:           {"-1", nil},
+		{"1", "e", 0}:             {"0", nil},
+		{"1", "10", 0}:            {"0", nil},
+		{"1", "2", 0}:             {"0", nil},
+		{"0", "e", 0}:             {"-Inf", nil},
+		{"-0", "10", 0}:           {"-Inf", nil},
+		{"0", "0.5", 0}:           {"Inf", nil},
+		{"Inf", "e", 0}:           {"Inf", nil},
+		{"Inf", "0.5", 0}:         {"-Inf", nil},
+		{"NaN", "e", 0}:           {"NaN", nil},
+		{"2", "NaN", 0}:           {"NaN", nil},
+		{"-2", "e", 0}:            {"NaN", decimal.ErrIndeterminate},
+		{"-Inf", "10", 0}:         {"NaN", decimal.ErrIndeterminate},
+		{"2", "1", 0}:             {"NaN", decimal.ErrIndeterminate},
+		{"2", "0", 0}:             {"NaN", decimal.ErrIndeterminate},
+		{"2", "-2", 0}:            {"NaN", decimal.ErrIndeterminate},
+		{"2", "Inf", 0}:           {"NaN", decimal.ErrIndeterminate},
+	}
+	for test, expected := range testCases {
+		var result decimal.Decimal
+		var err error
+		switch test.base {
+		case "e":
+			result, err = decimal.Ln(mustParse(t, test.x), test.precision)
+		case "10":
+			result, err = decimal.Log10(mustParse(t, test.x), test.precision)
+			if other, otherErr := decimal.Log(mustParse(t, test.x), mustParse(t, test.base), test.precision); other != result || otherErr != err {
+				t.Fatalf("Log(%s, 10) expected (%v, %v), instead got (%v, %v)", test.x, result, err, other, otherErr)
+			}
+		default:
+			result, err = decimal.Log(mustParse(t, test.x), mustParse(t, test.base), test.precision)
+		}
+		if !result.Equals(mustParse(t, expected.result)) || result.Sign != mustParse(t, expected.result).Sign || !errors.Is(err, expected.err) {
+			t.Fatalf("log_%s(%s) with precision %d expected (%s, %v), instead got (%v, %v)",
+				test.base, test.x, test.precision, expected.result, expected.err, result, err)
+		}
+	}
+	// Exact logarithms don't have trailing zeroes
+	if result, _ := decimal.Log(mustParse(t, "8"), mustParse(t, "4"), 0); result != (decimal.Decimal{Sign: true, Value: 15, PowerOfTen: -1}) {
+		t.Fatalf("Expected log4(8) to be exactly 1.5, instead got %v", result)
+	}
+}
+
+func TestExponentialContext(t *testing.T) {
+	inexact := decimal.ConditionInexact | decimal.ConditionRounded
+	testCases := map[struct {
+		x, op string
+		ctx   decimal.Context
+	}]struct {
+		result string
+		flags  decimal.Condition
+	}{
+		{"1", "exp", decimal.Context{Precision: 5}}:                                {"2.7183", inexact},
+		{"1", "exp", decimal.Context{Precision: 5, Rounding: decimal.RoundDown}}:   {"2.7182", inexact},
+		{"1e-30", "exp", decimal.Context{Precision: 5, Rounding: decimal.RoundUp}}: {"1.0001", inexact},
+		{"-1e-30", "exp", decimal.Context{Precision: 5, Rounding: decimal.RoundDown}}: {
+			"0.99999", inexact},
+		{"0", "exp", decimal.Context{}}:                                            {"1", 0},
+		{"1000", "exp", decimal.Context{ExponentLimits: true, Emax: 100}}:          {"Inf", decimal.ConditionOverflow | inexact},
+		{"2", "ln", decimal.Context{Precision: 3, Rounding: decimal.RoundCeiling}}: {"0.694", inexact},
+		{"0.5", "ln", decimal.Context{Precision: 3, Rounding: decimal.RoundCeiling}}: {
+			"-0.693", inexact},
+		{"-1", "ln", decimal.Context{}}:                                           {"NaN", decimal.ConditionInvalidOperation},
+		{"0", "ln", decimal.Context{}}:                                            {"-Inf", 0},
+		{"1e-8", "log10", decimal.Context{Precision: 3}}:                          {"-8", 0},
+		{"2", "log10", decimal.Context{Precision: 3, Rounding: decimal.RoundUp}}:  {"0.302", inexact},
+		{"8", "log2", decimal.Context{Precision: 3}}:                              {"3", 0},
+		{"3", "log2", decimal.Context{Precision: 3, Rounding: decimal.RoundDown}}: {"1.58", inexact},
+	}
+	for test, expected := range testCases {
+		ctx := test.ctx
+		result := decimal.Decimal{}
+		var err error
+		switch test.op {
+		case "exp":
+			err = ctx.Exp(&result, mustParse(t, test.x))
+		case "ln":
+			err = ctx.Ln(&result, mustParse(t, test.x))
+		case "log10":
+			err = ctx.Log10(&result, mustParse(t, test.x))
+		case "log2":
+			err = ctx.Log(&result, mustParse(t, test.x), decimal.DecimalFromInt(2))
+		}
+		if err != nil {
+			t.Fatalf("%s(%s) returned an error without traps: %v", test.op, test.x, err)
+		}
+		if !result.Equals(mustParse(t, expected.result)) || ctx.Flags != expected.flags {
+			t.Fatalf("%s(%s) with %+v expected (%s, %v), instead got (%v, %v)", test.op, test.x, test.ctx,
+				expected.result, expected.flags, result, ctx.Flags)
+		}
+	}
+}
+
+func TestBigExponential(t *testing.T) {
+	testCases := map[struct {
+		x, op     string
+		precision int
+	}]struct {
+		result string
+		ok     bool
+	}{
+		{"1", "exp", 50}:      {"2.7182818284590452353602874713526624977572470937000", true},
+		{"-1", "exp", 50}:     {"0.36787944117144232159552377016146086744581113103177", true},
+		{"0", "exp", 50}:      {"1", true},
+		{"1e20", "exp", 50}:   {"7", false},
+		{"-1e20", "exp", 50}:  {"0", false},
+		{"2", "ln", 50}:       {"0.69314718055994530941723212145817656807550013436026", true},
+		{"10", "ln", 50}:      {"2.3025850929940456840179914546843642076011014886288", true},
+		{"1", "ln", 50}:       {"0", true},
+		{"0", "ln", 50}:       {"7", false},
+		{"-2", "ln", 50}:      {"7", false},
+		{"2", "log10", 50}:    {"0.30102999566398119521373889472449302676818988146211", true},
+		{"1e-9", "log10", 50}: {"-9", true},
+		{"2", "log", 0}:       {"7", false},
+	}
+	for test, expected := range testCases {
+		x, err := decimal.ParseBigString(test.x)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", test.x, err)
+		}
+		// Failed operations leave the result unchanged
+		result := mustParseBig(t, "7")
+		var ok bool
+		switch test.op {
+		case "exp":
+			ok = result.Exp(x, test.precision)
+		case "ln":
+			ok = result.Ln(x, test.precision)
+		case "log10":
+			ok = result.Log10(x, test.precision)
+		case "log":
+			// Logarithm in base 1
+			ok = result.Log(x, mustParseBig(t, "1"), test.precision)
+		}
+		if want, _ := decimal.ParseBigString(expected.result); !result.Equals(want) || ok != expected.ok {
+			t.Fatalf("%s(%s) with precision %d expected (%s, %v), instead got (%s, %v)", test.op, test.x, test.precision,
+				expected.result, expected.ok, result.FormatText(true, false, -1), ok)
+		}
+	}
+	// A nil decimal is a noop
+	if (*decimal.BigDecimal)(nil).Exp(mustParseBig(t, "1"), 10) {
+		t.Fatal("Expected Exp on a nil BigDecimal to fail")
+	}
+}
+
+func BenchmarkExponential(b *testing.B) {
+	x := decimal.Decimal{Sign: true, Value: 105, PowerOfTen: -2}
+	b.Run("Exp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.Exp(x, 0)
+		}
+	})
+	b.Run("Ln", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.Ln(x, 0)
+		}
+	})
+	b.Run("BigExp", func(b *testing.B) {
+		bigX, _ := decimal.BigDecimalFromDecimal(x)
+		var result decimal.BigDecimal
+		for i := 0; i < b.N; i++ {
+			result.Exp(bigX, 100)
+		}
+	})
+}
+
+func FuzzExponential(f *testing.F) {
+	// Converts a decimal to a float64
+	toFloat := func(d decimal.Decimal) float64 {
+		f, _ := toRat(d).Float64()
+		return f
+	}
+	f.Add(true, uint64(1), int8(0))
+	f.Add(false, uint64(105), int8(-2))
+	f.Add(true, uint64(math.MaxUint64), int8(-17))
+	f.Add(true, uint64(3), int8(-9))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int8) {
+		x := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		if x.IsZero() {
+			return
+		}
+		// Compare with the float64 functions, far from overflows and underflows
+		if xFloat := toFloat(x); math.Abs(xFloat) < 700 {
+			result, err := decimal.Exp(x, 0)
+			if err != nil {
+				t.Fatalf("e^%v returned an unexpected error: %v", x, err)
+			}
+			if expected := math.Exp(xFloat); math.Abs(toFloat(result)-expected) > 1e-13*expected {
+				t.Fatalf("e^%v expected %v, instead got %v", x, expected, result)
+			}
+		}
+		if !x.Sign {
+			return
+		}
+		result, err := decimal.Ln(x, 0)
+		if err != nil {
+			t.Fatalf("ln(%v) returned an unexpected error: %v", x, err)
+		}
+		if expected := math.Log(toFloat(x)); math.Abs(toFloat(result)-expected) > 1e-13*math.Abs(expected) {
+			t.Fatalf("ln(%v) expected %v, instead got %v", x, expected, result)
+		}
+		// Log10 and Log in base 10 agree
+		log10, _ := decimal.Log10(x, 0)
+		if log, _ := decimal.Log(x, decimal.DecimalFromInt(10), 0); log != log10 {
+			t.Fatalf("log10(%v) is %v, but the logarithm in base 10 is %v", x, log10, log)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// Describes which digits are used to represent a float64 as a decimal
+type FloatMode uint8
+
+const (
+	// The shortest decimal that converts back to the same float64 (like strconv.FormatFloat with precision -1),
+	// for example 0.1 becomes 1 * 10^-1
+	FloatShortest FloatMode = iota
+	// The exact value of the float64, rounded with RoundHalfEven if it doesn't fit in a Decimal,
+	// for example 0.1 becomes 0.1000000000000000055511151231257827021181583404541015625 rounded to 20 digits
+	FloatExact
+)
+
+// The powers of ten that are exact float64 values
+var floatPowersOfTen = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11,
+	1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// Returns a decimal based on a given float64, with the digits selected by mode.
+// NaN and infinities become the matching special values.
+// Returns false if the decimal is not exactly equal to the float64
+//
+// Example:
+//
+//	decimal.NewFromFloat64(0.1, decimal.FloatShortest) // 1 * 10^-1, false
+//	decimal.NewFromFloat64(0.5, decimal.FloatExact)    // 5 * 10^-1, true
+func NewFromFloat64(f float64, mode FloatMode) (d Decimal, exact bool) {
+	// Special cases: NaN, infinities and zero
+	switch {
+	case math.IsNaN(f):
+		return NaN(), true
+	case math.IsInf(f, 0):
+		return Inf(f > 0), true
+	case f == 0:
+		return Decimal{Sign: !math.Signbit(f)}, true
+	}
+	if mode == FloatExact {
+		return floatExact(f)
+	}
+	// Read the digits from the shortest representation, like "-1.2345e-07"
+	var buffer [32]byte
+	text := strconv.AppendFloat(buffer[:0], f, 'e', -1, 64)
+	d.Sign = text[0] != '-'
+	if !d.Sign {
+		text = text[1:]
+	}
+	digits := int64(0)
+	for len(text) > 0 && text[0] != 'e' {
+		if text[0] != '.' {
+			d.Value = d.Value*10 + uint64(text[0]-'0')
+			digits++
+		}
+		text = text[1:]
+	}
+	// Note: the exponent of a float64 has at most three digits
+	exponent, _ := strconv.ParseInt(string(text[1:]), 10, 64)
+	d.PowerOfTen = exponent - digits + 1
+	_, exact = d.Float64()
+	return d, exact
+}
+
+// Returns the exact value of a finite non-zero float64, rounded to as many digits as fit in a Decimal.
+// Returns false if it was rounded
+func floatExact(f float64) (Decimal, bool) {
+	// f = mantissa * 2^exponent with an odd mantissa
+	mantissa, exponent := math.Frexp(math.Abs(f))
+	value := uint64(math.Ldexp(mantissa, 53))
+	exponent -= 53
+	shift := bits.TrailingZeros64(value)
+	value >>= shift
+	exponent += shift
+	// mantissa * 2^-k = mantissa * 5^k * 10^-k
+	coefficient := new(big.Int).SetUint64(value)
+	power := int64(0)
+	if exponent >= 0 {
+		coefficient.Lsh(coefficient, uint(exponent))
+	} else {
+		coefficient.Mul(coefficient, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-exponent)), nil))
+		power = int64(exponent)
+	}
+	d, cond := roundBigCoefficient(f > 0, coefficient, false, newWidePower(power), Context{})
+	d.Compress()
+	return d, cond&ConditionInexact == 0
+}
+
+// Returns the float64 closest to this decimal (rounding ties to even), and true if they are exactly equal.
+// Decimals too large or too small for a float64 return ±infinity or zero.
+// NaN and infinities return the matching float64 special values
+func (d Decimal) Float64() (f float64, exact bool) {
+	// Special cases: NaN, infinities and zero
+	switch {
+	case d.IsNaN():
+		return math.NaN(), true
+	case d.IsInf() && d.Sign:
+		return math.Inf(1), true
+	case d.IsInf():
+		return math.Inf(-1), true
+	case d.Value == 0 && d.Sign:
+		return 0, true
+	case d.Value == 0:
+		return math.Copysign(0, -1), true
+	}
+	f, exact, ok := d.float64Fast()
+	if !ok {
+		f, exact = d.float64Big()
+	}
+	if !d.Sign {
+		f = -f
+	}
+	return f, exact
+}
+
+// Returns the absolute value of this finite non-zero decimal as a float64, if it's cheap to compute.
+// When both the value and the power of ten are exact float64 values, a single
+// multiplication or division is correctly rounded.
+// Returns false if the slower float64Big is needed
+func (d Decimal) float64Fast() (f float64, exact bool, ok bool) {
+	if d.Value > 1<<53 || d.PowerOfTen < -22 || 22 < d.PowerOfTen {
+		return 0, false, false
+	}
+	if d.PowerOfTen >= 0 {
+		// Exact if the product fits in the mantissa
+		if d.PowerOfTen >= int64(len(powersOfTen)) {
+			return 0, false, false
+		}
+		if hi, lo := bits.Mul64(d.Value, powersOfTen[d.PowerOfTen]); hi == 0 && lo <= 1<<53 {
+			return float64(lo), true, true
+		}
+		return 0, false, false
+	}
+	// Exact if value / 10^k = (value / 5^k) / 2^k
+	k := -d.PowerOfTen
+	if five := powersOfFive[k]; d.Value%five == 0 {
+		return math.Ldexp(float64(d.Value/five), -int(k)), true, true
+	}
+	return float64(d.Value) / floatPowersOfTen[k], false, true
+}
+
+// Returns the absolute value of this finite non-zero decimal as a float64, using exact arithmetic
+func (d Decimal) float64Big() (f float64, exact bool) {
+	// Way outside the range of float64: the largest is about 1.8 * 10^308, the smallest is about 4.9 * 10^-324
+	adjusted := newWidePower(d.PowerOfTen).add(int64(uint128{lo: d.Value}.digits()) - 1)
+	if newWidePower(309).less(adjusted) {
+		return math.Inf(1), false
+	} else if adjusted.less(newWidePower(-325)) {
+		return 0, false
+	}
+	r := new(big.Rat).SetInt(new(big.Int).SetUint64(d.Value))
+	if d.PowerOfTen >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(bigPowerOfTen(uint64(d.PowerOfTen))))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(bigPowerOfTen(uint64(-d.PowerOfTen))))
+	}
+	return r.Float64()
+}
+
+// The powers of five that fit in a uint64, up to 5^22
+var powersOfFive = func() (powers [23]uint64) {
+	powers[0] = 1
+	for i := 1; i < len(powers); i++ {
+		powers[i] = powers[i-1] * 5
+	}
+	return powers
+}()
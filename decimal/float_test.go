@@ -0,0 +1,149 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestNewFromFloat64(t *testing.T) {
+	testCases := map[struct {
+		f    float64
+		mode decimal.FloatMode
+	}]struct {
+		result string
+		exact  bool
+	}{
+		{0.1, decimal.FloatShortest}:                         {"0.1", false},
+		{0.1, decimal.FloatExact}:                            {"0.10000000000000000555", false},
+		{1.0 / 3, decimal.FloatShortest}:                     {"0.3333333333333333", false},
+		{1.0 / 3, decimal.FloatExact}:                        {"0.3333333333333333148", false},
+		{2.5, decimal.FloatShortest}:                         {"2.5", true},
+		{2.5, decimal.FloatExact}:                            {"2.5", true},
+		{-123.456, decimal.FloatShortest}:                    {"-123.456", false},
+		{-123.456, decimal.FloatExact}:                       {"-123.45600000000000307", false},
+		{1e22, decimal.FloatShortest}:                        {"1e22", true},
+		{1e22, decimal.FloatExact}:                           {"1e22", true},
+		{1e300, decimal.FloatShortest}:                       {"1e300", false},
+		{1e300, decimal.FloatExact}:                          {"1.0000000000000000525e300", false},
+		{1 << 64, decimal.FloatShortest}:                     {"18446744073709552000", false},
+		{1 << 64, decimal.FloatExact}:                        {"1.844674407370955162e19", false},
+		{1 << 63, decimal.FloatExact}:                        {"9223372036854775808", true},
+		{math.MaxFloat64, decimal.FloatShortest}:             {"1.7976931348623157e308", false},
+		{math.MaxFloat64, decimal.FloatExact}:                {"1.7976931348623157081e308", false},
+		{math.SmallestNonzeroFloat64, decimal.FloatShortest}: {"5e-324", false},
+		{math.SmallestNonzeroFloat64, decimal.FloatExact}:    {"4.940656458412465442e-324", false},
+		{0, decimal.FloatShortest}:                           {"0", true},
+		{math.Copysign(0, -1), decimal.FloatExact}:           {"-0", true},
+		{math.Inf(1), decimal.FloatShortest}:                 {"Inf", true},
+		{math.Inf(-1), decimal.FloatExact}:                   {"-Inf", true},
+		{math.NaN(), decimal.FloatShortest}:                  {"NaN", true},
+	}
+	for test, expected := range testCases {
+		result, exact := decimal.NewFromFloat64(test.f, test.mode)
+		want := mustParse(t, expected.result)
+		if !result.Equals(want) || result.Sign != want.Sign || exact != expected.exact {
+			t.Fatalf("NewFromFloat64(%v, %d) expected (%s, %v), instead got (%v, %v)",
+				test.f, test.mode, expected.result, expected.exact, result, exact)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	testCases := map[string]struct {
+		f     float64
+		exact bool
+	}{
+		"0.1":                    {0.1, false},
+		"-2.5":                   {-2.5, true},
+		"123.456":                {123.456, false},
+		"0.125":                  {0.125, true},
+		"1e22":                   {1e22, true},
+		"1e23":                   {1e23, false},
+		"9007199254740993":       {9007199254740992, false},
+		"18446744073709551615":   {1 << 64, false},
+		"1.7976931348623157e308": {math.MaxFloat64, false},
+		"1.7976931348623159e308": {math.Inf(1), false},
+		"5e-324":                 {math.SmallestNonzeroFloat64, false},
+		"2e-324":                 {0, false},
+		"-3e-324":                {-math.SmallestNonzeroFloat64, false},
+		"1e9223372036854775807":  {math.Inf(1), false},
+		"-1e9223372036854775807": {math.Inf(-1), false},
+		"1e-9223372036854775807": {0, false},
+		"0":                      {0, true},
+		"Inf":                    {math.Inf(1), true},
+		"-Inf":                   {math.Inf(-1), true},
+	}
+	for test, expected := range testCases {
+		f, exact := mustParse(t, test).Float64()
+		if f != expected.f || exact != expected.exact {
+			t.Fatalf("%s expected (%v, %v), instead got (%v, %v)", test, expected.f, expected.exact, f, exact)
+		}
+	}
+	// Special cases: NaN and negative zero
+	if f, _ := decimal.NaN().Float64(); !math.IsNaN(f) {
+		t.Fatalf("NaN expected NaN, instead got %v", f)
+	}
+	if f, exact := (decimal.Decimal{}).Float64(); f != 0 || !math.Signbit(f) || !exact {
+		t.Fatalf("-0 expected (-0, true), instead got (%v, %v)", f, exact)
+	}
+}
+
+func BenchmarkFloat64(b *testing.B) {
+	b.Run("NewFromFloat64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.NewFromFloat64(123.456, decimal.FloatShortest)
+		}
+	})
+	b.Run("NewFromFloat64Exact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.NewFromFloat64(123.456, decimal.FloatExact)
+		}
+	})
+	b.Run("Float64", func(b *testing.B) {
+		d := decimal.Decimal{Sign: true, Value: 123456, PowerOfTen: -3}
+		for i := 0; i < b.N; i++ {
+			d.Float64()
+		}
+	})
+}
+
+func FuzzFloat64(f *testing.F) {
+	f.Add(true, uint64(123456), int16(-3), math.Float64bits(0.1))
+	f.Add(false, uint64(math.MaxUint64), int16(290), math.Float64bits(math.MaxFloat64))
+	f.Add(true, uint64(49), int16(-325), math.Float64bits(math.SmallestNonzeroFloat64))
+	f.Add(true, uint64(1<<53+1), int16(0), math.Float64bits(1e23))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int16, bits uint64) {
+		// Decimal to float64
+		d := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		result, exact := d.Float64()
+		expected, err := strconv.ParseFloat(d.FormatText(false, false, -1), 64)
+		if err != nil && !math.IsInf(expected, 0) {
+			t.Fatalf("strconv failed to parse %v: %v", d, err)
+		}
+		// Note: infinities are never exact
+		exactRat := !math.IsInf(result, 0) && new(big.Rat).SetFloat64(result).Cmp(toRat(d)) == 0
+		if result != expected || (value != 0 && exact != exactRat) {
+			t.Fatalf("%v expected (%v, %v), instead got (%v, %v)", d, expected, exactRat, result, exact)
+		}
+		// Float64 to decimal
+		x := math.Float64frombits(bits)
+		if math.IsNaN(x) || math.IsInf(x, 0) || x == 0 {
+			return
+		}
+		shortest, exact := decimal.NewFromFloat64(x, decimal.FloatShortest)
+		if expected, _ := decimal.ParseString(strconv.FormatFloat(x, 'e', -1, 64)); !shortest.Equals(expected) {
+			t.Fatalf("NewFromFloat64(%v) expected %v, instead got %v", x, expected, shortest)
+		}
+		if roundTrip, _ := shortest.Float64(); roundTrip != x || exact != (toRat(shortest).Cmp(new(big.Rat).SetFloat64(x)) == 0) {
+			t.Fatalf("NewFromFloat64(%v) returned (%v, %v) which converts back to %v", x, shortest, exact, roundTrip)
+		}
+		full, exact := decimal.NewFromFloat64(x, decimal.FloatExact)
+		if roundTrip, _ := full.Float64(); roundTrip != x || exact != (toRat(full).Cmp(new(big.Rat).SetFloat64(x)) == 0) {
+			t.Fatalf("NewFromFloat64(%v, FloatExact) returned (%v, %v) which converts back to %v", x, full, exact, roundTrip)
+		}
+	})
+}
@@ -1,8 +1,9 @@
 package decimal
 
 import (
+	"fmt"
+	"math"
 	"strconv"
-	"strings"
 )
 
 // Formats a number as a string, with the following parameters:
@@ -11,95 +12,423 @@ import (
 //   - accuracyLimit limts the maximum number of significant digits printed out; <=0 means unlimited
 //
 // Examples:
-//   - {true, 123, -2}.Format(true, false, 2): "12e-1"
-//   - {true, 123, -2}.Format(false, false, 0): "1.23"
-//   - {true, 123, -2}.Format(false, true, 0): "123"
-func (d Decimal) Format(asDecimal bool, asPercentage bool, accuracyLimit int) string {
+//   - {true, 123, -2}.FormatText(true, false, 2): "12e-1"
+//   - {true, 123, -2}.FormatText(false, false, 0): "1.23"
+//   - {true, 123, -2}.FormatText(false, true, 0): "123"
+//
+// Like String, numbers that would need more than 1000 zeroes use the power-of-ten notation even if asDecimal is false.
+// NaN and infinities are always formatted as "NaN", "Inf" and "-Inf".
+// This method used to be called Format, which now implements fmt.Formatter.
+func (d Decimal) FormatText(asDecimal bool, asPercentage bool, accuracyLimit int) string {
+	// Special case: NaN and infinities
+	switch {
+	case d.IsNaN():
+		return "NaN"
+	case d.IsInf() && d.Sign:
+		return "Inf"
+	case d.IsInf():
+		return "-Inf"
+	}
 	// Prepare number
 	d.Compress()
-	// Parepare core
-	core := strconv.FormatUint(d.Value, 10)
-	powerDelta := uint64(0)
+	var buffer [20]byte
+	return formatNumber(d.Sign, strconv.AppendUint(buffer[:0], d.Value, 10), d.PowerOfTen, asDecimal, asPercentage, accuracyLimit)
+}
+
+// Formats the number sign * core * 10^exponent as a string, see Decimal.FormatText for the parameters.
+// Like String, numbers that would need more than maxPlainZeroes zeroes use the power-of-ten notation
+func formatNumber(sign bool, core []byte, exponent int64, asDecimal bool, asPercentage bool, accuracyLimit int) string {
+	// Discard the digits past the accuracy limit and multiply percentages by 100:
+	// the number becomes core * 10^(exponent + shift)
+	shift := int64(0)
 	if accuracyLimit > 0 && len(core) > accuracyLimit {
-		powerDelta = uint64(len(core) - accuracyLimit)
+		shift = int64(len(core) - accuracyLimit)
 		core = core[:accuracyLimit]
 	}
-	// grab uint64 powerOfTen
-	powerOfTenPositive := true
-	if d.PowerOfTen < 0 {
-		powerOfTenPositive = false
-		d.PowerOfTen *= -1
-	}
-	powerOfTen := uint64(d.PowerOfTen)
-	// Adjust power of 10 based on core changes
-	if powerOfTenPositive {
-		powerOfTen += powerDelta
-	} else if powerOfTen > powerDelta {
-		powerOfTen -= powerDelta
-	} else {
-		powerOfTenPositive = true
-		powerOfTen = powerDelta - powerOfTen
-	}
-	// If we want a percentage, adjust accordingly
 	if asPercentage {
-		if powerOfTenPositive {
-			powerOfTen += 2
-		} else if powerOfTen > 2 {
-			powerOfTen -= 2
-		} else {
-			powerOfTenPositive = true
-			powerOfTen = 2 - powerOfTen
+		shift += 2
+	}
+	negative, magnitude := shiftedExponent(exponent, shift)
+	// Prepare result and add '-' if necessary
+	result := make([]byte, 0, len(core)+24)
+	if !sign {
+		result = append(result, '-')
+	}
+	switch {
+	case !asDecimal && !negative && magnitude <= maxPlainZeroes:
+		// 123 followed by zeroes
+		result = appendFixed(result, core, int64(magnitude), 0)
+	case !asDecimal && negative && magnitude <= maxPlainZeroes+uint64(len(core)):
+		// 1.23 or 0.00123
+		result = appendFixed(result, core, -int64(magnitude), int(magnitude))
+	default:
+		// Power-of-ten notation, like 123e-2
+		result = append(result, core...)
+		if magnitude != 0 {
+			result = append(result, 'e')
+			if negative {
+				result = append(result, '-')
+			}
+			result = strconv.AppendUint(result, magnitude, 10)
 		}
 	}
-	// Prepare builder and add '-' if necessary
-	resultBuilder := strings.Builder{}
+	// "%" end
+	if asPercentage {
+		result = append(result, '%')
+	}
+	return string(result)
+}
+
+// The largest number of zeroes String writes before switching to the power-of-ten notation
+const maxPlainZeroes = 1000
+
+// Returns the number in plain notation, like FormatText(false, false, 0).
+// Numbers that would need more than 1000 zeroes use the power-of-ten notation instead, like FormatText(true, false, 0)
+//
+// Examples:
+//   - {true, 123, -2}.String(): "1.23"
+//   - {false, 5, 3}.String(): "-5000"
+//   - {true, 1, 5000}.String(): "1e5000"
+func (d Decimal) String() string {
+	var buffer [32]byte
+	return string(d.appendString(buffer[:0]))
+}
+
+// Implementation of the TextMarshaler interface, returns the same text as String
+func (d Decimal) MarshalText() ([]byte, error) {
+	return d.appendString(make([]byte, 0, 24)), nil
+}
+
+// Implementation of the TextAppender interface, appends the same text as String to b
+func (d Decimal) AppendText(b []byte) ([]byte, error) {
+	return d.appendString(b), nil
+}
+
+// Appends the text of String to dst
+func (d Decimal) appendString(dst []byte) []byte {
+	if !d.IsFinite() || d.plain() {
+		return d.AppendFormat(dst, 'f', -1)
+	}
+	// Power-of-ten notation, like "-12e-1003"
+	d.Compress()
+	if !d.Sign {
+		dst = append(dst, '-')
+	}
+	dst = strconv.AppendUint(dst, d.Value, 10)
+	dst = append(dst, 'e')
+	return strconv.AppendInt(dst, d.PowerOfTen, 10)
+}
+
+// Returns true if the number can be written in plain notation with at most 1000 zeroes
+func (d Decimal) plain() bool {
+	d.Compress()
+	var buffer [20]byte
+	digits := int64(len(strconv.AppendUint(buffer[:0], d.Value, 10)))
+	return d.PowerOfTen <= maxPlainZeroes && d.PowerOfTen >= -maxPlainZeroes-digits
+}
+
+// Appends the number to dst formatted like strconv.AppendFloat, with the format fmt:
+//   - 'f': without exponent, like -1234.5678
+//   - 'e' or 'E': with exponent, like -1.2345678e+03
+//   - 'g' or 'G': 'e' for large exponents, 'f' otherwise
+//
+// prec is the number of digits after the decimal point ('f', 'e' and 'E') or the number of significant digits
+// ('g' and 'G'), and the digits are correctly rounded with RoundHalfEven.
+// A negative prec uses as many digits as needed to write the number exactly.
+// Like String, 'f' switches to the 'e' notation with all the digits of the number if it would need
+// more than 1000 zeroes before the decimal point (or after it, if prec is negative).
+// NaN and infinities are formatted as "NaN", "Inf" and "-Inf", an unknown format as '%' followed by it.
+//
+// Nothing is allocated if dst has enough capacity for the result.
+//
+// Examples:
+//   - {true, 12345, -3}.AppendFormat(nil, 'f', 2): "12.34"
+//   - {false, 5, 0}.AppendFormat(nil, 'e', 3): "-5.000e+00"
+//   - {true, 1, 21}.AppendFormat(nil, 'g', -1): "1e+21"
+func (d Decimal) AppendFormat(dst []byte, fmt byte, prec int) []byte {
+	// Special case: NaN and infinities
+	switch {
+	case d.IsNaN():
+		return append(dst, "NaN"...)
+	case d.IsInf() && d.Sign:
+		return append(dst, "Inf"...)
+	case d.IsInf():
+		return append(dst, "-Inf"...)
+	case fmt != 'f' && fmt != 'e' && fmt != 'E' && fmt != 'g' && fmt != 'G':
+		return append(dst, '%', fmt)
+	}
+	// Prepare the digits: coefficient * 10^power
+	d.Compress()
+	var buffer [20]byte
+	coefficient, power := strconv.AppendUint(buffer[:0], d.Value, 10), d.PowerOfTen
+	if d.Value == 0 {
+		power = 0
+	}
 	if !d.Sign {
-		resultBuilder.WriteByte('-')
-	}
-	// Process core
-	if asDecimal {
-		// Write this as a decimal
-		resultBuilder.WriteString(core)
-		if powerOfTen != 0 {
-			resultBuilder.WriteByte('e')
-			if !powerOfTenPositive {
-				resultBuilder.WriteByte('-')
+		dst = append(dst, '-')
+	}
+	switch fmt {
+	case 'f':
+		if power > maxPlainZeroes || (prec < 0 && !d.plain()) {
+			// Too many zeroes, use the power-of-ten notation with all the digits like String
+			return appendExponent(dst, coefficient, power, int64(len(coefficient)-1), len(coefficient)-1, 'e')
+		}
+		if prec < 0 {
+			prec = 0
+			if power < 0 {
+				// Note: the digits after the decimal point must be written anyway, so they fit in an int
+				prec = int(-power)
 			}
-			resultBuilder.WriteString(strconv.FormatUint(powerOfTen, 10))
 		}
+		return appendFixed(dst, coefficient, power, prec)
+	case 'e', 'E':
+		if prec < 0 {
+			prec = len(coefficient) - 1
+		}
+		return appendExponent(dst, coefficient, power, int64(len(coefficient)-1), prec, fmt)
+	}
+	return appendGeneral(dst, coefficient, power, prec, fmt-'g'+'e')
+}
+
+// Implementation of the fmt.Formatter interface, formats the number like a float64 with the following verbs:
+//   - %v and %s: the plain notation of String, or %g if a precision is given to %v
+//   - %f and %F: without exponent, with 6 digits after the decimal point unless a precision is given
+//   - %e and %E: with exponent, with 6 digits after the decimal point unless a precision is given
+//   - %g and %G: %e for large exponents, %f otherwise, with as many significant digits as needed unless a precision is given
+//   - %q: the plain notation of String, quoted
+//
+// Digits are correctly rounded with RoundHalfEven. The width and the '+', ' ', '-' and '0' flags work like for a float64,
+// so values that a float64 represents exactly are formatted the same way (except for %v, %s and %q).
+//
+// Examples:
+//   - fmt.Sprintf("%.2f", {true, 12345, -3}): "12.34"
+//   - fmt.Sprintf("%08.3e", {false, 5, 0}): "-5.000e+00"
+//   - fmt.Sprintf("%+g", {true, 1, 21}): "+1e+21"
+func (d Decimal) Format(f fmt.State, verb rune) {
+	precision, hasPrecision := f.Precision()
+	if !hasPrecision {
+		precision = -1
+	}
+	// Note: like for a float64, the '+' flag of %v doesn't print the sign
+	plus := f.Flag('+') && verb != 'v'
+	if verb == 'v' && hasPrecision {
+		verb = 'g'
+	}
+	// Format the number without its sign
+	var buffer [64]byte
+	abs := d
+	abs.Sign = true
+	var text []byte
+	switch verb {
+	case 'v', 's':
+		text = abs.appendString(buffer[:0])
+	case 'q':
+		text = append(d.appendString(append(buffer[:0], '"')), '"')
+	case 'f', 'F', 'e', 'E':
+		if precision < 0 {
+			precision = 6
+		}
+		format := byte(verb)
+		if verb == 'F' {
+			format = 'f'
+		}
+		text = abs.AppendFormat(buffer[:0], format, precision)
+	case 'g', 'G':
+		text = abs.AppendFormat(buffer[:0], byte(verb), precision)
+	default:
+		fmt.Fprintf(f, "%%!%c(decimal.Decimal=%s)", verb, d.String())
+		return
+	}
+	// Sign
+	sign := ""
+	switch {
+	case verb == 'q' || d.IsNaN() && !plus && !f.Flag(' '):
+	case !d.Sign && !d.IsNaN():
+		sign = "-"
+	case plus:
+		sign = "+"
+	case f.Flag(' '):
+		sign = " "
+	}
+	// Padding
+	width, _ := f.Width()
+	padding := width - len(sign) - len(text)
+	if padding < 0 {
+		padding = 0
+	}
+	result := make([]byte, 0, len(sign)+len(text)+padding)
+	switch {
+	case padding == 0:
+		result = append(append(result, sign...), text...)
+	case f.Flag('-'):
+		result = appendRepeated(append(append(result, sign...), text...), ' ', padding)
+	case f.Flag('0') && d.IsFinite() && verb != 's' && verb != 'q':
+		result = append(appendRepeated(append(result, sign...), '0', padding), text...)
+	default:
+		result = append(append(appendRepeated(result, ' ', padding), sign...), text...)
+	}
+	f.Write(result)
+}
+
+// Appends coefficient * 10^power with prec digits after the decimal point, like 'f'
+func appendFixed(dst []byte, coefficient []byte, power int64, prec int) []byte {
+	if power >= 0 {
+		dst = appendRepeated(append(dst, coefficient...), '0', int(power))
+		if prec > 0 {
+			dst = appendRepeated(append(dst, '.'), '0', prec)
+		}
+		return dst
+	}
+	// Note: n is less than len(coefficient) + prec, so it can't overflow
+	n := int64(len(coefficient)) + power + int64(prec)
+	digits, _ := roundDigitBytes(coefficient, n)
+	// The digits followed by padding zeroes are the number * 10^prec
+	padding := 0
+	if n > int64(len(digits)) {
+		padding = int(n) - len(digits)
+	}
+	whole := len(digits) + padding - prec
+	if whole > 0 {
+		dst = append(dst, digits[:whole]...)
 	} else {
-		// Core part
-		if !powerOfTenPositive {
-			// 0.00123 or 1.23
-			if powerOfTen < uint64(len(core)) {
-				resultBuilder.WriteString(core[0:powerOfTen])
-				resultBuilder.WriteByte('.')
-			} else {
-				// Write first zeroes
-				resultBuilder.WriteString("0.")
-				for i := powerOfTen - uint64(len(core)); i > 0; i-- {
-					resultBuilder.WriteByte('0')
-				}
-				powerOfTen = uint64(len(core))
-			}
-			if powerOfTen <= uint64(len(core)) {
-				resultBuilder.WriteString(core[uint64(len(core))-powerOfTen:])
-			}
-		} else {
-			// 123
-			resultBuilder.WriteString(core)
+		dst = append(dst, '0')
+	}
+	if prec == 0 {
+		return dst
+	}
+	dst = append(dst, '.')
+	if whole < 0 {
+		dst = appendRepeated(dst, '0', -whole)
+		whole = 0
+	}
+	return appendRepeated(append(dst, digits[whole:]...), '0', padding)
+}
+
+// Appends the digits with one digit before the decimal point and prec after it, followed by the letter e
+// and the exponent of the first digit (power + shift, at least two digits), like 'e'.
+// Note: shift can't be negative, power + shift is computed without overflowing
+func appendExponent(dst []byte, digits []byte, power int64, shift int64, prec int, e byte) []byte {
+	digits, carry := roundDigitBytes(digits, int64(prec)+1)
+	if carry {
+		digits = digits[:len(digits)-1]
+		shift++
+	}
+	dst = append(dst, digits[0])
+	if prec > 0 {
+		dst = appendRepeated(append(append(dst, '.'), digits[1:]...), '0', prec+1-len(digits))
+	}
+	// Exponent
+	dst = append(dst, e, '+')
+	negative, magnitude := shiftedExponent(power, shift)
+	if negative {
+		dst[len(dst)-1] = '-'
+	}
+	if magnitude < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendUint(dst, magnitude, 10)
+}
+
+// Returns the sign and the magnitude of power + shift, computed without overflowing.
+// Note: shift can't be negative
+func shiftedExponent(power int64, shift int64) (negative bool, magnitude uint64) {
+	switch {
+	case power >= 0:
+		return false, uint64(power) + uint64(shift)
+	case power+shift >= 0:
+		return false, uint64(power + shift)
+	}
+	return true, uint64(-(power + shift + 1)) + 1
+}
+
+// Appends coefficient * 10^power with prec significant digits (or as many as needed if prec is negative)
+// like 'e' if the exponent is less than -4 or greater than or equal to the precision, like 'f' otherwise.
+// Trailing zeroes are removed, like 'g'
+func appendGeneral(dst []byte, coefficient []byte, power int64, prec int, e byte) []byte {
+	shortest := prec < 0
+	if shortest {
+		prec = len(coefficient)
+	} else if prec == 0 {
+		prec = 1
+	}
+	// Round to the precision, removing the trailing zeroes.
+	// The first digit is digits[0] * 10^(power + shift)
+	shift := int64(len(coefficient) - 1)
+	digits, carry := roundDigitBytes(coefficient, int64(prec))
+	if carry {
+		digits = digits[:len(digits)-1]
+		shift++
+	}
+	for len(digits) > 1 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+	}
+	// Choose the notation like strconv, the exponent of the first digit is only used if it fits in an int64
+	large, exponent := power > math.MaxInt64-shift, power+shift
+	exponentLimit := int64(prec)
+	if shortest {
+		exponentLimit = 6
+	} else if prec > len(digits) && !large && exponent < int64(len(digits)) {
+		exponentLimit = int64(len(digits))
+	}
+	if large || exponent < -4 || exponent >= exponentLimit {
+		if prec > len(digits) {
+			prec = len(digits)
 		}
-		// "000" end
-		if powerOfTenPositive {
-			// Add zeroes
-			for i := uint64(0); i < powerOfTen; i++ {
-				resultBuilder.WriteByte('0')
-			}
+		return appendExponent(dst, digits, power, shift, prec-1, e)
+	}
+	// Note: the exponent is small, so the position of the decimal point is too
+	point := int(exponent) + 1
+	if prec > point {
+		prec = len(digits)
+	}
+	decimals := 0
+	if prec > point {
+		decimals = prec - point
+	}
+	return appendFixed(dst, digits, exponent+1-int64(len(digits)), decimals)
+}
+
+// Rounds the digits to the first n (with RoundHalfEven) in place, returning them unchanged if n >= len(digits).
+// If the rounding carries into a new digit, it returns n+1 digits ("1" followed by zeroes) and true
+func roundDigitBytes(digits []byte, n int64) ([]byte, bool) {
+	switch {
+	case n >= int64(len(digits)):
+		return digits, false
+	case n < 0:
+		return digits[:0], false
+	}
+	first := digits[n]
+	rest := false
+	for _, digit := range digits[n+1:] {
+		rest = rest || digit != '0'
+	}
+	if first < '5' || (first == '5' && !rest && (n == 0 || (digits[n-1]-'0')%2 == 0)) {
+		return digits[:n], false
+	}
+	// Round up
+	for i := n - 1; i >= 0; i-- {
+		if digits[i] != '9' {
+			digits[i]++
+			return digits[:n], false
 		}
+		digits[i] = '0'
 	}
-	// "%" end
-	if asPercentage {
-		resultBuilder.WriteByte('%')
+	// Note: n < len(digits), so there is space for the new digit
+	digits = digits[:n+1]
+	digits[n] = '0'
+	digits[0] = '1'
+	return digits, true
+}
+
+// Appends n copies of c to dst, growing it at most once
+func appendRepeated(dst []byte, c byte, n int) []byte {
+	if n <= 0 {
+		return dst
+	}
+	start := len(dst)
+	dst = append(dst, make([]byte, n)...)
+	for i := start; i < len(dst); i++ {
+		dst[i] = c
 	}
-	return resultBuilder.String()
+	return dst
 }
@@ -1,6 +1,10 @@
 package decimal_test
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stefanovazzocell/GoDecimal/decimal"
@@ -31,6 +35,19 @@ func TestFormat(t *testing.T) {
 		{"0.000123", false, false, 0}:  "0.000123",
 		{"0.0123%", false, true, 2}:    "0.012%",
 		{"1234.5", false, false, 2}:    "1200",
+		{"-12.345", false, false, 0}:   "-12.345",
+		{"12.345", false, false, 4}:    "12.34",
+		{"NaN", false, false, 0}:       "NaN",
+		{"inf", true, true, 2}:         "Inf",
+		{"-Infinity", false, true, 0}:  "-Inf",
+		// Numbers that would need too many zeroes use the power-of-ten notation
+		{"1e1001", false, false, 0}:                  "1e1001",
+		{"12e-1003", false, false, 0}:                "12e-1003",
+		{"1e4611686018427387904", false, false, 0}:   "1e4611686018427387904",
+		{"-1e-4611686018427387904", false, true, 0}:  "-1e-4611686018427387902%",
+		{"1e9223372036854775807", true, true, 0}:     "1e9223372036854775809%",
+		{"-1e-9223372036854775807", false, false, 0}: "-1e-9223372036854775807",
+		{"123e9223372036854775807", false, false, 1}: "1e9223372036854775809",
 	}
 
 	for test, expected := range testCases {
@@ -38,9 +55,273 @@ func TestFormat(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to setup test: %v", err)
 		}
-		actual := number.Format(test.asDecimal, test.asPercentage, test.accuracyLimit)
+		actual := number.FormatText(test.asDecimal, test.asPercentage, test.accuracyLimit)
 		if actual != expected {
 			t.Fatalf("Expected %q for %v (%v), instead got %q", expected, test, number, actual)
 		}
 	}
 }
+
+func TestFormatVerbs(t *testing.T) {
+	testCases := map[struct {
+		format string
+		number string
+	}]string{
+		{"%v", "1.23"}:                      "1.23",
+		{"%v", "-5e3"}:                      "-5000",
+		{"%v", "-0"}:                        "-0",
+		{"%+v", "12"}:                       "12",
+		{"%.3v", "1234.5"}:                  "1.23e+03",
+		{"%s", "0.00012"}:                   "0.00012",
+		{"%8s", "-1.5"}:                     "    -1.5",
+		{"%08s", "-1.5"}:                    "    -1.5",
+		{"%q", "-1.5"}:                      `"-1.5"`,
+		{"%f", "1.5"}:                       "1.500000",
+		{"%.2f", "12.345"}:                  "12.34",
+		{"%.2f", "12.355"}:                  "12.36",
+		{"%.2f", "12.3451"}:                 "12.35",
+		{"%.2f", "0.125"}:                   "0.12",
+		{"%.0f", "0.5"}:                     "0",
+		{"%.0f", "1.5"}:                     "2",
+		{"%.0f", "-9.5"}:                    "-10",
+		{"%.1f", "0.0001"}:                  "0.0",
+		{"%.1f", "-0.0001"}:                 "-0.0",
+		{"%F", "123e2"}:                     "12300.000000",
+		{"%.3f", "1e-20"}:                   "0.000",
+		{"%e", "0"}:                         "0.000000e+00",
+		{"%e", "123456789"}:                 "1.234568e+08",
+		{"%.2E", "-0.000999"}:               "-9.99E-04",
+		{"%.1e", "9.96"}:                    "1.0e+01",
+		{"%.0e", "25"}:                      "2e+01",
+		{"%e", "1e-100"}:                    "1.000000e-100",
+		{"%.1e", "123e9223372036854775800"}: "1.2e+9223372036854775802",
+		{"%g", "0.0001"}:                    "0.0001",
+		{"%g", "0.00001"}:                   "1e-05",
+		{"%g", "123456"}:                    "123456",
+		{"%g", "1234567"}:                   "1.234567e+06",
+		{"%g", "1e21"}:                      "1e+21",
+		{"%g", "12.345"}:                    "12.345",
+		{"%.3g", "12.345"}:                  "12.3",
+		{"%.3g", "99.95"}:                   "100",
+		{"%.3g", "1000"}:                    "1e+03",
+		{"%.10g", "0.1"}:                    "0.1",
+		{"%G", "1e-7"}:                      "1E-07",
+		{"%g", "0"}:                         "0",
+		{"%g", "-0"}:                        "-0",
+		{"%+.2f", "1"}:                      "+1.00",
+		{"% .2f", "1"}:                      " 1.00",
+		{"% .2f", "-1"}:                     "-1.00",
+		{"%8.2f", "-1"}:                     "   -1.00",
+		{"%-8.2f|", "-1"}:                   "-1.00   |",
+		{"%08.2f", "-1"}:                    "-0001.00",
+		{"%+08.2f", "1"}:                    "+0001.00",
+		{"%-08.2f|", "1"}:                   "1.00    |",
+		{"%f", "NaN"}:                       "NaN",
+		{"%+f", "NaN"}:                      "+NaN",
+		{"% f", "NaN"}:                      " NaN",
+		{"%f", "-Inf"}:                      "-Inf",
+		{"%+g", "Inf"}:                      "+Inf",
+		{"%06f", "-Inf"}:                    "  -Inf",
+		{"%v", "NaN"}:                       "NaN",
+		{"%d", "12"}:                        "%!d(decimal.Decimal=12)",
+	}
+
+	for test, expected := range testCases {
+		number, err := decimal.ParseString(test.number)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		actual := fmt.Sprintf(test.format, number)
+		if actual != expected {
+			t.Fatalf("Expected %q for %q of %q, instead got %q", expected, test.format, test.number, actual)
+		}
+	}
+	// Pointers are formatted like values
+	if number := mustParse(t, "1.5"); fmt.Sprintf("%.2f", &number) != "1.50" {
+		t.Fatalf("Expected %q for a pointer, instead got %q", "1.50", fmt.Sprintf("%.2f", &number))
+	}
+}
+
+func TestString(t *testing.T) {
+	testCases := map[decimal.Decimal]string{
+		{Sign: true, Value: 123, PowerOfTen: -2}:     "1.23",
+		{Sign: false, Value: 5, PowerOfTen: 3}:       "-5000",
+		{Sign: true, Value: 0, PowerOfTen: 7}:        "0",
+		{Sign: true, Value: 1, PowerOfTen: 1000}:     "1" + strings.Repeat("0", 1000),
+		{Sign: true, Value: 1, PowerOfTen: 1001}:     "1e1001",
+		{Sign: true, Value: 5000, PowerOfTen: 997}:   "5" + strings.Repeat("0", 1000),
+		{Sign: true, Value: 12, PowerOfTen: -1001}:   "0." + strings.Repeat("0", 999) + "12",
+		{Sign: false, Value: 12, PowerOfTen: -1003}:  "-12e-1003",
+		{Sign: true, Value: 1, PowerOfTen: 5000}:     "1e5000",
+		{Sign: true, Value: 1, PowerOfTen: -1 << 62}: "1e-4611686018427387904",
+		decimal.NaN():      "NaN",
+		decimal.Inf(false): "-Inf",
+	}
+
+	for number, expected := range testCases {
+		if actual := number.String(); actual != expected {
+			t.Fatalf("Expected %q for %v, instead got %q", expected, number.FormatText(true, false, 0), actual)
+		}
+	}
+}
+
+func TestAppendFormat(t *testing.T) {
+	testCases := map[struct {
+		number string
+		fmt    byte
+		prec   int
+	}]string{
+		{"12.345", 'f', 2}:   "12.34",
+		{"12.345", 'f', -1}:  "12.345",
+		{"-12e3", 'f', -1}:   "-12000",
+		{"0.00012", 'f', -1}: "0.00012",
+		{"9.999", 'f', 1}:    "10.0",
+		{"0.06", 'f', 1}:     "0.1",
+		{"0.04", 'f', 0}:     "0",
+		{"123456", 'e', -1}:  "1.23456e+05",
+		{"-5", 'e', 3}:       "-5.000e+00",
+		{"999.5", 'E', 2}:    "1.00E+03",
+		{"1e21", 'g', -1}:    "1e+21",
+		{"0.1", 'g', -1}:     "0.1",
+		{"123.456", 'G', 4}:  "123.5",
+		{"-0", 'g', -1}:      "-0",
+		{"NaN", 'f', 2}:      "NaN",
+		{"Inf", 'e', 2}:      "Inf",
+		{"-Inf", 'g', 2}:     "-Inf",
+		{"1", 'x', 2}:        "%x",
+		// Numbers that would need too many zeroes use the 'e' notation
+		{"1e4611686018427387904", 'f', 2}:     "1e+4611686018427387904",
+		{"-15e-4611686018427387904", 'f', -1}: "-1.5e-4611686018427387903",
+		{"15e-4611686018427387904", 'f', 2}:   "0.00",
+		{"1e1001", 'f', 0}:                    "1e+1001",
+	}
+
+	for test, expected := range testCases {
+		number, err := decimal.ParseString(test.number)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		// The result is appended to the destination
+		if actual := string(number.AppendFormat([]byte("x="), test.fmt, test.prec)); actual != "x="+expected {
+			t.Fatalf("Expected %q for %q, %c, %d, instead got %q", "x="+expected, test.number, test.fmt, test.prec, actual)
+		}
+	}
+	// Exponents that don't fit in an int64
+	if actual := string(decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MinInt64}.AppendFormat(nil, 'e', -1)); actual != "1e-9223372036854775808" {
+		t.Fatalf("Expected %q, instead got %q", "1e-9223372036854775808", actual)
+	}
+	for format, prec := range map[byte]int{'e': 0, 'g': 1} {
+		if actual := string(decimal.Decimal{Sign: true, Value: 15, PowerOfTen: math.MaxInt64}.AppendFormat(nil, format, prec)); actual != "2e+9223372036854775808" {
+			t.Fatalf("Expected %q for %c, instead got %q", "2e+9223372036854775808", format, actual)
+		}
+	}
+	// The number of zeroes is limited like for String
+	if actual, expected := mustParse(t, "1e1000").FormatText(false, false, 0), "1"+strings.Repeat("0", 1000); actual != expected {
+		t.Fatalf("Expected %q, instead got %q", expected, actual)
+	}
+	if actual, expected := mustParse(t, "12e-1002").FormatText(false, false, 0), "0."+strings.Repeat("0", 1000)+"12"; actual != expected {
+		t.Fatalf("Expected %q, instead got %q", expected, actual)
+	}
+	if actual := fmt.Sprintf("%f", decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 1 << 62}); actual != "1e+4611686018427387904" {
+		t.Fatalf("Expected %q, instead got %q", "1e+4611686018427387904", actual)
+	}
+	// Nothing is allocated if the destination is large enough
+	number, buffer := mustParse(t, "-1234567.891"), make([]byte, 0, 64)
+	for _, format := range []byte{'f', 'e', 'g'} {
+		if allocs := testing.AllocsPerRun(100, func() { number.AppendFormat(buffer, format, 20) }); allocs != 0 {
+			t.Fatalf("Expected no allocations for %c, instead got %v", format, allocs)
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() { number.AppendText(buffer) }); allocs != 0 {
+		t.Fatalf("Expected no allocations for AppendText, instead got %v", allocs)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	testCases := map[decimal.Decimal]string{
+		{Sign: true, Value: 150, PowerOfTen: -2}:  "1.5",
+		{Sign: false, Value: 12, PowerOfTen: 2}:   "-1200",
+		{Sign: false, Value: 0, PowerOfTen: 0}:    "-0",
+		{Sign: true, Value: 7, PowerOfTen: 1234}:  "7e1234",
+		{Sign: true, Value: 7, PowerOfTen: -1234}: "7e-1234",
+		decimal.NaN():     "NaN",
+		decimal.Inf(true): "Inf",
+	}
+
+	for number, expected := range testCases {
+		text, err := number.MarshalText()
+		if err != nil || string(text) != expected {
+			t.Fatalf("Expected %q for %v, instead got (%q, %v)", expected, number, text, err)
+		}
+		if text, err = number.AppendText([]byte("x=")); err != nil || string(text) != "x="+expected {
+			t.Fatalf("Expected %q for %v, instead got (%q, %v)", "x="+expected, number, text, err)
+		}
+		// Round trip
+		var parsed decimal.Decimal
+		if err := parsed.UnmarshalText([]byte(expected)); err != nil || !parsed.Equals(number) {
+			t.Fatalf("Expected %v after parsing %q, instead got (%v, %v)", number, expected, parsed, err)
+		}
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	number := decimal.Decimal{Sign: false, Value: 123456789, PowerOfTen: -4}
+	buffer := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buffer = number.AppendFormat(buffer[:0], 'f', 2)
+		buffer, _ = number.AppendText(buffer)
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	number := decimal.Decimal{Sign: false, Value: 123456789, PowerOfTen: -4}
+	for i := 0; i < b.N; i++ {
+		_ = number.String()
+		_ = fmt.Sprintf("%10.2f", number)
+	}
+}
+
+func FuzzFormat(f *testing.F) {
+	f.Add(math.Float64bits(12.5), uint8(0), uint8(0), int8(0), int8(2))
+	f.Add(math.Float64bits(-0.125), uint8(1), uint8(3), int8(10), int8(-1))
+	f.Add(math.Float64bits(1e21), uint8(4), uint8(1), int8(-1), int8(4))
+	f.Add(math.Float64bits(math.Copysign(0, -1)), uint8(2), uint8(8), int8(8), int8(0))
+	f.Fuzz(func(t *testing.T, bits uint64, verb uint8, flags uint8, width int8, precision int8) {
+		x := math.Float64frombits(bits)
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return
+		}
+		// Only numbers with the same digits as the shortest float64 representation
+		number, exact := decimal.NewFromFloat64(x, decimal.FloatShortest)
+		if !exact {
+			return
+		}
+		// Build the format, like "%+-8.2e"
+		format := "%"
+		for i, flag := range "+- 0" {
+			if flags&(1<<i) != 0 {
+				format += string(flag)
+			}
+		}
+		if width > 0 {
+			format += strconv.Itoa(int(width % 40))
+		}
+		if precision >= 0 {
+			format += "." + strconv.Itoa(int(precision%40))
+		}
+		format += string("fFeEgG"[verb%6])
+		if expected, actual := fmt.Sprintf(format, x), fmt.Sprintf(format, number); actual != expected {
+			t.Fatalf("Expected %q for %q of %v, instead got %q", expected, format, x, actual)
+		}
+		// AppendFormat works like strconv.AppendFloat, including the shortest precision
+		prec := int(precision % 40)
+		if precision < 0 {
+			prec = -1
+		}
+		fmtByte := "feEgG"[verb%5]
+		if expected, actual := strconv.FormatFloat(x, fmtByte, prec, 64), string(number.AppendFormat(nil, fmtByte, prec)); actual != expected {
+			t.Fatalf("Expected %q for %c, %d of %v, instead got %q", expected, fmtByte, prec, x, actual)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package decimal
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrorParsingFraction = errors.New("the given string is not a valid fraction")
+)
+
+// Parse a fraction like "3/8" or a mixed number like "1 1/2" from a given string,
+// and divide it to the closest decimal with `precision` significant digits using the given rounding mode
+// (a precision <= 0 keeps as many digits as fit in a Decimal).
+// Returns false if the result had to be rounded.
+//
+// Notes:
+//  1. The whole part, numerator and denominator are each parsed with ParseString, so they can have decimals or exponents.
+//  2. Only the whole number can have a sign, which applies to the fraction too: "-1 1/2" is -1.5.
+//  3. Strings without a '/' are parsed with ParseString and then rounded.
+//  4. A zero denominator returns ErrDivisionByZero.
+//
+// Examples:
+//  1. "3/8" parses as 0.375 (exact)
+//  2. "-1 1/2" parses as -1.5 (exact)
+//  3. "2/3" with precision 4 and RoundHalfEven parses as 0.6667 (inexact)
+func ParseFraction(numberStr string, precision int, mode RoundingMode) (d Decimal, exact bool, err error) {
+	sign, whole, numerator, denominator, err := splitFraction(numberStr)
+	switch {
+	case err != nil:
+		return Decimal{}, false, err
+	case denominator == nil:
+		// Not a fraction
+		d, cond := div(*whole, DecimalFromInt(1), Context{Precision: precision, Rounding: mode})
+		return d, cond&ConditionInexact == 0, nil
+	case denominator.IsZero():
+		return Decimal{}, false, ErrDivisionByZero
+	}
+	wholeRat, numeratorRat, denominatorRat := whole.Rat(), numerator.Rat(), denominator.Rat()
+	if wholeRat == nil || numeratorRat == nil || denominatorRat == nil {
+		return Decimal{}, false, ErrorParsingOverflow
+	}
+	r := wholeRat.Add(wholeRat, numeratorRat.Quo(numeratorRat, denominatorRat))
+	if !sign {
+		r.Neg(r)
+	}
+	d, exact = NewFromRat(r, precision, mode)
+	return d, exact, nil
+}
+
+// Parse a fraction like "3/8" or a mixed number like "1 1/2" from a given string as an exact rational,
+// following the rules described in ParseFraction.
+// Returns ErrorParsingFraction for NaN and infinities,
+// and ErrorParsingOverflow if the numerator or denominator of the result don't fit in an uint64
+//
+// Example:
+//
+//	third, err := decimal.ParseRational("1/3") // {true, 1, 3}, nil
+func ParseRational(numberStr string) (Rational, error) {
+	sign, whole, numerator, denominator, err := splitFraction(numberStr)
+	switch {
+	case err != nil:
+		return Rational{}, err
+	case denominator == nil:
+		// Not a fraction
+		if !whole.IsFinite() {
+			return Rational{}, ErrorParsingFraction
+		}
+		r, ok := RationalFromDecimal(*whole)
+		if !ok {
+			return Rational{}, ErrorParsingOverflow
+		}
+		return r, nil
+	case denominator.IsZero():
+		return Rational{}, ErrDivisionByZero
+	}
+	wholeRational, wholeOk := RationalFromDecimal(*whole)
+	numeratorRational, numeratorOk := RationalFromDecimal(*numerator)
+	denominatorRational, denominatorOk := RationalFromDecimal(*denominator)
+	r := Rational{}
+	if !wholeOk || !numeratorOk || !denominatorOk ||
+		!r.Div(numeratorRational, denominatorRational) || !r.Add(wholeRational, r) {
+		return Rational{}, ErrorParsingOverflow
+	}
+	if r.Numerator != 0 {
+		r.Sign = sign
+	}
+	return r, nil
+}
+
+// Splits a fraction string into its (positive) parts and its sign.
+// The whole part is zero for simple fractions, while numerator and denominator are nil for strings without a '/'
+func splitFraction(numberStr string) (sign bool, whole, numerator, denominator *Decimal, err error) {
+	numberStr = strings.TrimSpace(numberStr)
+	left, right, isFraction := strings.Cut(numberStr, "/")
+	if !isFraction {
+		d, err := ParseString(numberStr)
+		return d.Sign, &d, nil, nil, err
+	}
+	// Sign
+	sign = true
+	if len(left) > 0 && (left[0] == '+' || left[0] == '-') {
+		sign = left[0] == '+'
+		left = left[1:]
+	}
+	// Parts
+	parts := strings.Fields(left)
+	if len(parts) == 1 {
+		parts = []string{"0", parts[0]}
+	}
+	right = strings.TrimSpace(right)
+	if len(parts) != 2 || right == "" || strings.ContainsAny(right, "/ \t\n") {
+		return false, nil, nil, nil, ErrorParsingFraction
+	}
+	decimals := [3]Decimal{}
+	for i, part := range []string{parts[0], parts[1], right} {
+		if part[0] == '+' || part[0] == '-' {
+			return false, nil, nil, nil, ErrorParsingFraction
+		}
+		if decimals[i], err = ParseString(part); err != nil {
+			return false, nil, nil, nil, err
+		}
+		if !decimals[i].IsFinite() {
+			return false, nil, nil, nil, ErrorParsingFraction
+		}
+	}
+	return sign, &decimals[0], &decimals[1], &decimals[2], nil
+}
@@ -0,0 +1,133 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestParseFraction(t *testing.T) {
+	testCases := map[struct {
+		numberStr string
+		precision int
+		mode      decimal.RoundingMode
+	}]struct {
+		result string
+		exact  bool
+		err    error
+	}{
+		{"3/8", 0, decimal.RoundHalfEven}:                     {"0.375", true, nil},
+		{"1 1/2", 0, decimal.RoundHalfEven}:                   {"1.5", true, nil},
+		{"-1 1/2", 0, decimal.RoundHalfEven}:                  {"-1.5", true, nil},
+		{" +2  3/4 ", 0, decimal.RoundHalfEven}:               {"2.75", true, nil},
+		{"-3/8", 2, decimal.RoundHalfEven}:                    {"-0.38", false, nil},
+		{"2/3", 4, decimal.RoundHalfEven}:                     {"0.6667", false, nil},
+		{"2/3", 4, decimal.RoundDown}:                         {"0.6666", false, nil},
+		{"1/3", 0, decimal.RoundHalfEven}:                     {"0.3333333333333333333", false, nil},
+		{"1.5/2", 0, decimal.RoundHalfEven}:                   {"0.75", true, nil},
+		{"1e30/7", 3, decimal.RoundHalfEven}:                  {"1.43e29", false, nil},
+		{"0/5", 0, decimal.RoundHalfEven}:                     {"0", true, nil},
+		{"12.5", 2, decimal.RoundHalfEven}:                    {"12", false, nil},
+		{"12.5", 0, decimal.RoundHalfEven}:                    {"12.5", true, nil},
+		{"-Inf", 0, decimal.RoundHalfEven}:                    {"-Inf", true, nil},
+		{"1/0", 0, decimal.RoundHalfEven}:                     {"", false, decimal.ErrDivisionByZero},
+		{"1 2 3/4", 0, decimal.RoundHalfEven}:                 {"", false, decimal.ErrorParsingFraction},
+		{"/3", 0, decimal.RoundHalfEven}:                      {"", false, decimal.ErrorParsingFraction},
+		{"3/", 0, decimal.RoundHalfEven}:                      {"", false, decimal.ErrorParsingFraction},
+		{"1/2/3", 0, decimal.RoundHalfEven}:                   {"", false, decimal.ErrorParsingFraction},
+		{"1 -1/2", 0, decimal.RoundHalfEven}:                  {"", false, decimal.ErrorParsingFraction},
+		{"1/-2", 0, decimal.RoundHalfEven}:                    {"", false, decimal.ErrorParsingFraction},
+		{"Inf/2", 0, decimal.RoundHalfEven}:                   {"", false, decimal.ErrorParsingFraction},
+		{"1/1e9223372036854775807", 0, decimal.RoundHalfEven}: {"", false, decimal.ErrorParsingOverflow},
+	}
+	for test, expected := range testCases {
+		result, exact, err := decimal.ParseFraction(test.numberStr, test.precision, test.mode)
+		if !errors.Is(err, expected.err) {
+			t.Fatalf("ParseFraction(%q, %d, %d) expected error %v, instead got %v", test.numberStr, test.precision, test.mode, expected.err, err)
+		}
+		if err == nil && (!result.Equals(mustParse(t, expected.result)) || exact != expected.exact) {
+			t.Fatalf("ParseFraction(%q, %d, %d) expected (%s, %v), instead got (%v, %v)",
+				test.numberStr, test.precision, test.mode, expected.result, expected.exact, result, exact)
+		}
+	}
+}
+
+func TestParseRational(t *testing.T) {
+	testCases := map[string]struct {
+		result string
+		err    error
+	}{
+		"3/8":                      {"3/8", nil},
+		"6/16":                     {"3/8", nil},
+		"-1 1/2":                   {"-3/2", nil},
+		"1.5/2":                    {"3/4", nil},
+		"12.50":                    {"25/2", nil},
+		"-0 0/7":                   {"0", nil},
+		"18446744073709551615/3":   {"6148914691236517205", nil},
+		"18446744073709551615 1/2": {"", decimal.ErrorParsingOverflow},
+		"1/1e20":                   {"", decimal.ErrorParsingOverflow},
+		"1/0":                      {"", decimal.ErrDivisionByZero},
+		"NaN":                      {"", decimal.ErrorParsingFraction},
+		"1 2/3/4":                  {"", decimal.ErrorParsingFraction},
+	}
+	for test, expected := range testCases {
+		result, err := decimal.ParseRational(test)
+		if !errors.Is(err, expected.err) || (err == nil && result.String() != expected.result) {
+			t.Fatalf("ParseRational(%q) expected (%s, %v), instead got (%v, %v)", test, expected.result, expected.err, result, err)
+		}
+	}
+	// ParseString still stops at the first '/'
+	if d := mustParse(t, "3/8"); !d.Equals(decimal.DecimalFromInt(3)) {
+		t.Fatalf("ParseString(\"3/8\") expected 3, instead got %v", d)
+	}
+}
+
+func BenchmarkParseFraction(b *testing.B) {
+	b.Run("ParseFraction", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.ParseFraction("-1 1/3", 10, decimal.RoundHalfEven)
+		}
+	})
+	b.Run("ParseRational", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.ParseRational("-1 1/3")
+		}
+	})
+}
+
+func FuzzParseFraction(f *testing.F) {
+	f.Add(false, uint32(1), uint32(1), uint32(2), uint8(0))
+	f.Add(true, uint32(0), uint32(2), uint32(3), uint8(4))
+	f.Add(true, uint32(math.MaxUint32), uint32(math.MaxUint32), uint32(7), uint8(19))
+	f.Fuzz(func(t *testing.T, sign bool, whole, numerator, denominator uint32, precision uint8) {
+		numberStr := strconv.FormatUint(uint64(whole), 10) + " " + strconv.FormatUint(uint64(numerator), 10) +
+			"/" + strconv.FormatUint(uint64(denominator), 10)
+		if !sign {
+			numberStr = "-" + numberStr
+		}
+		if denominator == 0 {
+			if _, _, err := decimal.ParseFraction(numberStr, 0, decimal.RoundHalfEven); err != decimal.ErrDivisionByZero {
+				t.Fatalf("ParseFraction(%q) expected ErrDivisionByZero, instead got %v", numberStr, err)
+			}
+			return
+		}
+		exact := new(big.Rat).Add(big.NewRat(int64(whole), 1), big.NewRat(int64(numerator), int64(denominator)))
+		if !sign {
+			exact.Neg(exact)
+		}
+		// The decimal is the fraction correctly rounded
+		result, ok, err := decimal.ParseFraction(numberStr, int(precision%25), decimal.RoundHalfEven)
+		if expected, expectedOk := decimal.NewFromRat(exact, int(precision%25), decimal.RoundHalfEven); err != nil || !result.Equals(expected) || ok != expectedOk {
+			t.Fatalf("ParseFraction(%q, %d) expected (%v, %v), instead got (%v, %v, %v)", numberStr, precision%25, expected, expectedOk, result, ok, err)
+		}
+		// The rational is the fraction in lowest terms
+		r, err := decimal.ParseRational(numberStr)
+		if err != nil || r.String() != exact.RatString() {
+			t.Fatalf("ParseRational(%q) expected %v, instead got (%v, %v)", numberStr, exact.RatString(), r, err)
+		}
+	})
+}
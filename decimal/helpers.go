@@ -15,7 +15,7 @@ func gcd(x, y uint64) uint64 {
 }
 
 // Calculate the Least Common Multiple (LCM) between two uint64
-// Returns (0, false) if an overflow is detected
+// Returns (0, true) if an overflow is detected
 func lcm(x, y uint64) (uint64, bool) {
 	if x == 0 || y == 0 {
 		return 0, false
@@ -58,3 +58,27 @@ func overflow_multiplication(x, y uint64) bool {
 	}
 	return math.MaxUint64/x < y
 }
+
+// All the powers of ten that can be represented in a uint64
+var powersOfTen = [...]uint64{
+	1,
+	10,
+	100,
+	1000,
+	10000,
+	100000,
+	1000000,
+	10000000,
+	100000000,
+	1000000000,
+	10000000000,
+	100000000000,
+	1000000000000,
+	10000000000000,
+	100000000000000,
+	1000000000000000,
+	10000000000000000,
+	100000000000000000,
+	1000000000000000000,
+	10000000000000000000,
+}
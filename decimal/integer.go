@@ -0,0 +1,96 @@
+package decimal
+
+import "math"
+
+// Returns true if the number is finite and has no fractional part
+func (d Decimal) IsInteger() bool {
+	switch {
+	case !d.IsFinite():
+		return false
+	case d.Value == 0 || d.PowerOfTen >= 0:
+		return true
+	case d.PowerOfTen <= -int64(len(powersOfTen)):
+		// The value is smaller than 10^20
+		return false
+	}
+	return d.Value%powersOfTen[-d.PowerOfTen] == 0
+}
+
+// Returns the number as an int64.
+// Returns false if the number is not finite, it has a fractional part or it doesn't fit in an int64
+//
+// Example:
+//
+//	cents, ok := price.Times(decimal.DecimalFromInt(100)).Decimal().Int64()
+func (d Decimal) Int64() (i int64, ok bool) {
+	value, ok := d.integerValue()
+	switch {
+	case !ok:
+		return 0, false
+	case d.Sign && value <= math.MaxInt64:
+		return int64(value), true
+	case !d.Sign && value <= 1<<63:
+		// Note: -value wraps around to the two's complement, which also works for math.MinInt64
+		return int64(-value), true
+	}
+	return 0, false
+}
+
+// Returns the number as an uint64.
+// Returns false if the number is not finite, it has a fractional part, it's negative or it doesn't fit in an uint64
+func (d Decimal) Uint64() (u uint64, ok bool) {
+	value, ok := d.integerValue()
+	if !ok || (!d.Sign && value != 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// Returns the absolute value of the number as an integer.
+// Returns false if the number is not finite, it has a fractional part or it doesn't fit in an uint64
+func (d Decimal) integerValue() (uint64, bool) {
+	switch {
+	case !d.IsInteger():
+		return 0, false
+	case d.Value == 0:
+		return 0, true
+	case d.PowerOfTen < 0:
+		return d.Value / powersOfTen[-d.PowerOfTen], true
+	case d.PowerOfTen >= int64(len(powersOfTen)) || overflow_multiplication(d.Value, powersOfTen[d.PowerOfTen]):
+		return 0, false
+	}
+	return d.Value * powersOfTen[d.PowerOfTen], true
+}
+
+// Returns the largest integer less than or equal to the number.
+// NaN and infinities are returned unchanged
+//
+// Examples:
+//   - {true, 125, -1}.Floor(): {true, 12, 0}
+//   - {false, 125, -1}.Floor(): {false, 13, 0}
+func (d Decimal) Floor() Decimal {
+	d.Round(0, RoundFloor)
+	return d
+}
+
+// Returns the smallest integer greater than or equal to the number.
+// NaN and infinities are returned unchanged
+//
+// Examples:
+//   - {true, 125, -1}.Ceil(): {true, 13, 0}
+//   - {false, 125, -1}.Ceil(): {false, 12, 0}
+func (d Decimal) Ceil() Decimal {
+	d.Round(0, RoundCeiling)
+	return d
+}
+
+// Returns the integer part of the number, discarding any fractional part.
+// NaN and infinities are returned unchanged
+//
+// Examples:
+//   - {true, 125, -1}.Trunc(): {true, 12, 0}
+//   - {false, 125, -1}.Trunc(): {false, 12, 0}
+func (d Decimal) Trunc() Decimal {
+	d.Round(0, RoundDown)
+	return d
+}
@@ -0,0 +1,134 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestIntegers(t *testing.T) {
+	testCases := map[string]struct {
+		isInteger bool
+		i         int64
+		iOk       bool
+		u         uint64
+		uOk       bool
+	}{
+		"0":                        {true, 0, true, 0, true},
+		"-0":                       {true, 0, true, 0, true},
+		"0e-30":                    {true, 0, true, 0, true},
+		"42":                       {true, 42, true, 42, true},
+		"-42":                      {true, -42, true, 0, false},
+		"4200e-2":                  {true, 42, true, 42, true},
+		"42.5":                     {false, 0, false, 0, false},
+		"-0.001":                   {false, 0, false, 0, false},
+		"12e3":                     {true, 12000, true, 12000, true},
+		"9223372036854775807":      {true, math.MaxInt64, true, math.MaxInt64, true},
+		"-9223372036854775808":     {true, math.MinInt64, true, 0, false},
+		"9223372036854775808":      {true, 0, false, 1 << 63, true},
+		"-9223372036854775809":     {true, 0, false, 0, false},
+		"18446744073709551615":     {true, 0, false, math.MaxUint64, true},
+		"1844674407370955162e1":    {true, 0, false, 0, false},
+		"1e19":                     {true, 0, false, 1e19, true},
+		"1e20":                     {true, 0, false, 0, false},
+		"1e9223372036854775807":    {true, 0, false, 0, false},
+		"1e-9223372036854775807":   {false, 0, false, 0, false},
+		"10000000000000000000e-19": {true, 1, true, 1, true},
+		"Inf":                      {false, 0, false, 0, false},
+		"-Inf":                     {false, 0, false, 0, false},
+		"NaN":                      {false, 0, false, 0, false},
+	}
+	for test, expected := range testCases {
+		d := mustParse(t, test)
+		if isInteger := d.IsInteger(); isInteger != expected.isInteger {
+			t.Fatalf("%s.IsInteger() expected %v, instead got %v", test, expected.isInteger, isInteger)
+		}
+		if i, ok := d.Int64(); i != expected.i || ok != expected.iOk {
+			t.Fatalf("%s.Int64() expected (%d, %v), instead got (%d, %v)", test, expected.i, expected.iOk, i, ok)
+		}
+		if u, ok := d.Uint64(); u != expected.u || ok != expected.uOk {
+			t.Fatalf("%s.Uint64() expected (%d, %v), instead got (%d, %v)", test, expected.u, expected.uOk, u, ok)
+		}
+	}
+}
+
+func TestFloorCeilTrunc(t *testing.T) {
+	testCases := map[string]struct {
+		floor, ceil, trunc decimal.Decimal
+	}{
+		"12.5":                   {decimal.DecimalFromInt(12), decimal.DecimalFromInt(13), decimal.DecimalFromInt(12)},
+		"-12.5":                  {decimal.DecimalFromInt(-13), decimal.Decimal{Sign: false, Value: 12}, decimal.Decimal{Sign: false, Value: 12}},
+		"7":                      {decimal.DecimalFromInt(7), decimal.DecimalFromInt(7), decimal.DecimalFromInt(7)},
+		"0.001":                  {decimal.DecimalFromInt(0), decimal.DecimalFromInt(1), decimal.DecimalFromInt(0)},
+		"-0.001":                 {decimal.DecimalFromInt(-1), decimal.Decimal{}, decimal.Decimal{}},
+		"1e-9223372036854775807": {decimal.DecimalFromInt(0), decimal.DecimalFromInt(1), decimal.DecimalFromInt(0)},
+		"1e9223372036854775807": {
+			decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64},
+			decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64},
+			decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64},
+		},
+		"-18446744073709551615e-1": {
+			decimal.Decimal{Sign: false, Value: 1844674407370955162},
+			decimal.Decimal{Sign: false, Value: 1844674407370955161},
+			decimal.Decimal{Sign: false, Value: 1844674407370955161},
+		},
+		"Inf":  {decimal.Inf(true), decimal.Inf(true), decimal.Inf(true)},
+		"-Inf": {decimal.Inf(false), decimal.Inf(false), decimal.Inf(false)},
+		"NaN":  {decimal.NaN(), decimal.NaN(), decimal.NaN()},
+	}
+	for test, expected := range testCases {
+		d := mustParse(t, test)
+		if floor, ceil, trunc := d.Floor(), d.Ceil(), d.Trunc(); floor != expected.floor || ceil != expected.ceil || trunc != expected.trunc {
+			t.Fatalf("%s expected floor %v, ceil %v and trunc %v, instead got %v, %v and %v",
+				test, expected.floor, expected.ceil, expected.trunc, floor, ceil, trunc)
+		}
+	}
+	// The number itself is not changed
+	d := decimal.Decimal{Sign: true, Value: 125, PowerOfTen: -1}
+	if d.Floor(); d != (decimal.Decimal{Sign: true, Value: 125, PowerOfTen: -1}) {
+		t.Fatalf("Floor changed the number to %v", d)
+	}
+}
+
+func BenchmarkIntegers(b *testing.B) {
+	d := decimal.Decimal{Sign: false, Value: 123400, PowerOfTen: -2}
+	b.Run("Int64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Int64()
+		}
+	})
+	b.Run("Floor", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Floor()
+		}
+	})
+}
+
+func FuzzIntegers(f *testing.F) {
+	f.Add(true, uint64(12345), int8(-2))
+	f.Add(false, uint64(9223372036854775808), int8(0))
+	f.Add(true, uint64(math.MaxUint64), int8(-19))
+	f.Add(true, uint64(18), int8(18))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int8) {
+		d := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		exact := toRat(d)
+		if d.IsInteger() != exact.IsInt() {
+			t.Fatalf("%v.IsInteger() expected %v", d, exact.IsInt())
+		}
+		if i, ok := d.Int64(); ok != (exact.IsInt() && exact.Num().IsInt64()) || (ok && i != exact.Num().Int64()) {
+			t.Fatalf("%v.Int64() returned (%d, %v)", d, i, ok)
+		}
+		if u, ok := d.Uint64(); ok != (exact.IsInt() && exact.Num().IsUint64()) || (ok && u != exact.Num().Uint64()) {
+			t.Fatalf("%v.Uint64() returned (%d, %v)", d, u, ok)
+		}
+		// Floor and Ceil are the closest integers around the number, and Trunc is one of them
+		floor, ceil, trunc := toRat(d.Floor()), toRat(d.Ceil()), toRat(d.Trunc())
+		if !floor.IsInt() || !ceil.IsInt() || floor.Cmp(exact) > 0 || ceil.Cmp(exact) < 0 ||
+			new(big.Rat).Sub(ceil, floor).Cmp(big.NewRat(1, 1)) > 0 ||
+			(exact.Sign() >= 0 && trunc.Cmp(floor) != 0) || (exact.Sign() < 0 && trunc.Cmp(ceil) != 0) {
+			t.Fatalf("%v expected floor %v, ceil %v and trunc %v around %v", d, floor, ceil, trunc, exact)
+		}
+	})
+}
@@ -0,0 +1,302 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+)
+
+var (
+	ErrorParsingGrouping = errors.New("the given string has inconsistent digit grouping")
+)
+
+// Describes how numbers are written in a given locale
+type Locale struct {
+	// The decimal separator, "." if empty
+	Decimal string
+	// The accepted grouping separators, the first one is the preferred one
+	Group []string
+	// The size of the groups of digits, starting from the decimal separator. The last size repeats,
+	// so {3} groups by thousands and {3, 2} is the Indian grouping (12,34,567). Defaults to {3}
+	Grouping []int
+	// How FormatLocale writes negative numbers, the first "n" is replaced by the number. Defaults to "-n"
+	Negative string
+	// How FormatLocale writes percentages, the first "n" is replaced by the number. Defaults to "n%"
+	Percent string
+	// How FormatCurrency writes amounts, the first "n" is replaced by the number and "¤" by the currency symbol.
+	// Defaults to "¤n"
+	Currency string
+}
+
+var (
+	// English (United States): 1,234,567.89
+	LocaleUS = Locale{Decimal: ".", Group: []string{","}, Grouping: []int{3}, Negative: "-n", Percent: "n%", Currency: "¤n"}
+	// German (Germany): 1.234.567,89
+	LocaleDE = Locale{Decimal: ",", Group: []string{"."}, Grouping: []int{3}, Negative: "-n", Percent: "n\u00a0%", Currency: "n\u00a0¤"}
+	// French (France): 1 234 567,89 with a narrow no-break space, a no-break space or a space
+	LocaleFR = Locale{Decimal: ",", Group: []string{"\u202f", "\u00a0", " "}, Grouping: []int{3}, Negative: "-n", Percent: "n\u202f%", Currency: "n\u00a0¤"}
+	// German (Switzerland): 1'234'567.89 with an apostrophe or a right single quotation mark
+	LocaleCH = Locale{Decimal: ".", Group: []string{"\u2019", "'"}, Grouping: []int{3}, Negative: "-n", Percent: "n%", Currency: "¤\u00a0n"}
+	// English (India): 12,34,567.89
+	LocaleIN = Locale{Decimal: ".", Group: []string{","}, Grouping: []int{3, 2}, Negative: "-n", Percent: "n%", Currency: "¤n"}
+	// Italian (Italy): 1.234.567,89
+	LocaleIT = Locale{Decimal: ",", Group: []string{"."}, Grouping: []int{3}, Negative: "-n", Percent: "n%", Currency: "n\u00a0¤"}
+	// Dutch (Netherlands) and Portuguese (Brazil): 1.234.567,89 with the currency symbol first
+	LocaleNL = Locale{Decimal: ",", Group: []string{"."}, Grouping: []int{3}, Negative: "-n", Percent: "n%", Currency: "¤\u00a0n"}
+)
+
+// Common locales by their language tag
+var Locales = map[string]Locale{
+	"en-US": LocaleUS,
+	"en-GB": LocaleUS,
+	"en-IN": LocaleIN,
+	"de-DE": LocaleDE,
+	"de-CH": LocaleCH,
+	"fr-FR": LocaleFR,
+	"it-IT": LocaleIT,
+	"nl-NL": LocaleNL,
+	"pt-BR": LocaleNL,
+	"ja-JP": LocaleUS,
+	"zh-CN": LocaleUS,
+}
+
+// The FractionDigits that round a number to a whole number in FormatLocale
+const NoFractionDigits = -1
+
+// Options for FormatLocale
+type FormatOptions struct {
+	// The number of digits after the decimal separator, rounded with Rounding.
+	// If zero, as many as needed, so that nothing is ever rounded away by default.
+	// Use NoFractionDigits (or any negative number) to round to a whole number
+	FractionDigits int
+	// The rounding mode used for FractionDigits, RoundHalfEven by default
+	Rounding RoundingMode
+	// If true, the number is multiplied by 100 and written with the Percent pattern of the locale
+	Percent bool
+}
+
+// Parse a decimal number written in the given locale, returning a *ParseError if it's not a well-formed number.
+// Accepts the same grammar as ParseStrict, using the decimal separator of the locale and
+// optionally grouping the digits of the whole part with one of its grouping separators.
+// Returns ErrorParsingGrouping (wrapped in a *ParseError) if the digits are grouped inconsistently.
+//
+// Examples:
+//  1. "1.234.567,89" with LocaleDE parses as 123456789 * 10 ^ -2
+//  2. "1234567,89" with LocaleDE parses as 123456789 * 10 ^ -2
+//  3. "12,34,567" with LocaleIN parses as 1234567
+//  4. "1.23.456,7" with LocaleDE fails with "inconsistent digit grouping" at offset 1
+//  5. "1.5" with LocaleFR fails with "unexpected character '.'" at offset 1
+func ParseLocale(numberStr string, loc Locale) (Decimal, error) {
+	fail := func(offset int, err error, reason string) (Decimal, error) {
+		return Decimal{}, &ParseError{Input: numberStr, Offset: offset, Reason: reason, Err: err}
+	}
+	decimalSeparator := loc.Decimal
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+	// Translate the number for ParseStrict, remembering where each byte comes from
+	canonical := make([]byte, 0, len(numberStr))
+	offsets := make([]int, 0, len(numberStr)+1)
+	i := 0
+	if i < len(numberStr) && (numberStr[i] == '+' || numberStr[i] == '-') {
+		canonical, offsets = append(canonical, numberStr[i]), append(offsets, i)
+		i++
+	}
+	// Whole part, with the size of each group and the position of each separator
+	groups, separators := []int{0}, []int{}
+	separator := ""
+	for i < len(numberStr) {
+		if '0' <= numberStr[i] && numberStr[i] <= '9' {
+			canonical, offsets = append(canonical, numberStr[i]), append(offsets, i)
+			groups[len(groups)-1]++
+			i++
+			continue
+		}
+		if strings.HasPrefix(numberStr[i:], decimalSeparator) {
+			break
+		}
+		group := matchSeparator(numberStr[i:], loc.Group)
+		switch {
+		case group == "":
+			// Not part of the whole number
+		case separator != "" && group != separator:
+			return fail(i, ErrorParsingGrouping, "mixed grouping separators")
+		case groups[len(groups)-1] == 0:
+			return fail(i, ErrorParsingGrouping, "empty group of digits")
+		default:
+			separator = group
+			groups, separators = append(groups, 0), append(separators, i)
+			i += len(group)
+			continue
+		}
+		break
+	}
+	if separator != "" {
+		if offset, ok := checkGrouping(groups, separators, loc.Grouping); !ok {
+			return fail(offset, ErrorParsingGrouping, "inconsistent digit grouping")
+		}
+	}
+	// Decimal part, exponent and percentage
+	if strings.HasPrefix(numberStr[i:], decimalSeparator) {
+		canonical, offsets = append(canonical, '.'), append(offsets, i)
+		i += len(decimalSeparator)
+	}
+	for ; i < len(numberStr); i++ {
+		if numberStr[i] == '.' {
+			// Only the decimal separator of the locale is accepted
+			return fail(i, ErrorParsingSyntax, "unexpected character '.'")
+		}
+		canonical, offsets = append(canonical, numberStr[i]), append(offsets, i)
+	}
+	offsets = append(offsets, len(numberStr))
+	d, err := ParseStrict(string(canonical))
+	if parseError, ok := err.(*ParseError); ok {
+		// Report the error for the original string
+		parseError.Input, parseError.Offset = numberStr, offsets[parseError.Offset]
+	}
+	return d, err
+}
+
+// Returns the longest separator that the string starts with, or an empty string
+func matchSeparator(numberStr string, separators []string) string {
+	match := ""
+	for _, separator := range separators {
+		if len(separator) > len(match) && strings.HasPrefix(numberStr, separator) {
+			match = separator
+		}
+	}
+	return match
+}
+
+// Checks that the sizes of the groups of digits (from the most significant) follow the grouping,
+// the most significant group can be shorter.
+// Returns the offset of the separator before the first wrong group and false if they don't
+func checkGrouping(groups []int, separators []int, grouping []int) (int, bool) {
+	if len(grouping) == 0 {
+		grouping = []int{3}
+	}
+	for j := 0; j < len(groups); j++ {
+		// j counts the groups from the decimal separator
+		expected := grouping[len(grouping)-1]
+		if j < len(grouping) {
+			expected = grouping[j]
+		}
+		k := len(groups) - 1 - j
+		if k == 0 && groups[k] > expected {
+			return separators[0], false
+		}
+		if k > 0 && groups[k] != expected {
+			return separators[k-1], false
+		}
+	}
+	return 0, true
+}
+
+// Formats a number in the given locale, grouping the digits of the whole part with the first grouping separator
+// and writing negative numbers and percentages with the patterns of the locale.
+// With the zero FormatOptions all the digits of the number are written: set FractionDigits to round it,
+// or to NoFractionDigits to round it to a whole number.
+// A number that is zero after rounding is never negative, so -0.001 with 2 fraction digits is "0.00".
+// NaN and infinities are formatted like String, and so are numbers that would need more than 1000 zeroes.
+//
+// Examples:
+//  1. -1234567.891 with LocaleDE and 2 fraction digits: "-1.234.567,89"
+//  2. -1234567.891 with LocaleDE and the zero FormatOptions: "-1.234.567,891"
+//  3. 1234567.891 with LocaleIN and NoFractionDigits: "12,34,568"
+//  4. 0.1234 with LocaleFR, 1 fraction digit and Percent: "12,3 %" (with narrow no-break spaces)
+//  5. -12.5 with Locale{Negative: "(n)"} and as many fraction digits as needed: "(12.5)"
+func FormatLocale(d Decimal, loc Locale, opts FormatOptions) string {
+	if opts.Percent && d.IsFinite() && d.Value != 0 {
+		// 1 == 100%, overflows like the arithmetic operations
+		if d.PowerOfTen > math.MaxInt64-2 {
+			d = Inf(d.Sign)
+		} else {
+			d.PowerOfTen += 2
+		}
+	}
+	if !d.IsFinite() {
+		return d.String()
+	}
+	fractionDigits := opts.FractionDigits
+	switch {
+	case fractionDigits == 0:
+		fractionDigits = -1 // As many as needed
+	case fractionDigits < 0:
+		fractionDigits = 0
+	}
+	number, negative := formatLocaleNumber(d, loc, fractionDigits, opts.Rounding)
+	if opts.Percent {
+		number = applyPattern(loc.Percent, "n%", number)
+	}
+	if negative {
+		number = applyPattern(loc.Negative, "-n", number)
+	}
+	return number
+}
+
+// Formats the absolute value of a finite number in the given locale, rounded to fractionDigits with mode
+// (unless fractionDigits is negative). Returns true if the rounded number is negative
+func formatLocaleNumber(d Decimal, loc Locale, fractionDigits int, mode RoundingMode) (string, bool) {
+	if fractionDigits >= 0 {
+		d.Round(int64(fractionDigits), mode)
+	}
+	negative := !d.Sign && d.Value != 0
+	d.Sign = true
+	if !d.plain() {
+		return d.String(), negative
+	}
+	decimalSeparator := loc.Decimal
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+	var buffer [64]byte
+	text := d.AppendFormat(buffer[:0], 'f', fractionDigits)
+	whole, fraction := text, []byte(nil)
+	if point := bytes.IndexByte(text, '.'); point >= 0 {
+		whole, fraction = text[:point], text[point+1:]
+	}
+	result := appendGrouped(make([]byte, 0, len(text)+8), whole, loc)
+	if len(fraction) > 0 {
+		result = append(append(result, decimalSeparator...), fraction...)
+	}
+	return string(result), negative
+}
+
+// Appends the digits of the whole part to dst, grouped with the first grouping separator of the locale
+func appendGrouped(dst []byte, whole []byte, loc Locale) []byte {
+	if len(loc.Group) == 0 {
+		return append(dst, whole...)
+	}
+	grouping := loc.Grouping
+	if len(grouping) == 0 {
+		grouping = []int{3}
+	}
+	// Returns the size of the j-th group from the decimal separator
+	size := func(j int) int {
+		if j < len(grouping) {
+			return grouping[j]
+		}
+		return grouping[len(grouping)-1]
+	}
+	// Find the size of the most significant group and how many groups follow it
+	first, groups := len(whole), 0
+	for size(groups) > 0 && first > size(groups) {
+		first -= size(groups)
+		groups++
+	}
+	dst = append(dst, whole[:first]...)
+	for j := groups - 1; j >= 0; j-- {
+		dst = append(append(dst, loc.Group[0]...), whole[first:first+size(j)]...)
+		first += size(j)
+	}
+	return dst
+}
+
+// Replaces the first "n" in the pattern (or the fallback, if the pattern is empty) with the number
+func applyPattern(pattern string, fallback string, number string) string {
+	if pattern == "" {
+		pattern = fallback
+	}
+	return strings.Replace(pattern, "n", number, 1)
+}
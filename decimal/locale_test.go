@@ -0,0 +1,225 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestParseLocale(t *testing.T) {
+	testCases := map[struct {
+		numberStr string
+		locale    string
+	}]struct {
+		decimal decimal.Decimal
+		offset  int
+		err     error
+	}{
+		{"1,234,567.89", "US"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1234567.89", "US"}:                    {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"-999,999", "US"}:                      {decimal.Decimal{Sign: false, Value: 999999}, 0, nil},
+		{"1.234.567,89", "DE"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1234567,89", "DE"}:                    {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"-0,5%", "DE"}:                         {decimal.Decimal{Sign: false, Value: 5, PowerOfTen: -3}, 0, nil},
+		{"1,5e3", "DE"}:                         {decimal.Decimal{Sign: true, Value: 15, PowerOfTen: 2}, 0, nil},
+		{"1 234 567,89", "FR"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1 234 567,89", "FR"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1'234'567.89", "CH"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1’234.5", "CH"}:                       {decimal.Decimal{Sign: true, Value: 12345, PowerOfTen: -1}, 0, nil},
+		{"12,34,567.89", "IN"}:                  {decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -2}, 0, nil},
+		{"1,00,00,000", "IN"}:                   {decimal.Decimal{Sign: true, Value: 10000000}, 0, nil},
+		{"999", "IN"}:                           {decimal.Decimal{Sign: true, Value: 999}, 0, nil},
+		{"1.23.456,7", "DE"}:                    {decimal.Decimal{}, 1, decimal.ErrorParsingGrouping},
+		{"1234.567,7", "DE"}:                    {decimal.Decimal{}, 4, decimal.ErrorParsingGrouping},
+		{"1.234.56", "DE"}:                      {decimal.Decimal{}, 5, decimal.ErrorParsingGrouping},
+		{"1..234", "DE"}:                        {decimal.Decimal{}, 2, decimal.ErrorParsingGrouping},
+		{".234", "DE"}:                          {decimal.Decimal{}, 0, decimal.ErrorParsingGrouping},
+		{"1.234.", "DE"}:                        {decimal.Decimal{}, 5, decimal.ErrorParsingGrouping},
+		{"1 234 567", "FR"}:                     {decimal.Decimal{}, 5, decimal.ErrorParsingGrouping},
+		{"12,345,678", "IN"}:                    {decimal.Decimal{}, 2, decimal.ErrorParsingGrouping},
+		{"1,234,567", "IN"}:                     {decimal.Decimal{}, 1, decimal.ErrorParsingGrouping},
+		{"1.234,5.6", "DE"}:                     {decimal.Decimal{}, 7, decimal.ErrorParsingSyntax},
+		{"1,234,5", "DE"}:                       {decimal.Decimal{}, 5, decimal.ErrorParsingSyntax},
+		{"1.5", "FR"}:                           {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		{"1,234.5,6", "US"}:                     {decimal.Decimal{}, 7, decimal.ErrorParsingSyntax},
+		{"12 345abc", "FR"}:                     {decimal.Decimal{}, 6, decimal.ErrorParsingSyntax},
+		{"1'234'567'890'123'456'789'012", "CH"}: {decimal.Decimal{}, 27, decimal.ErrorParsingPrecision},
+	}
+	locales := map[string]decimal.Locale{
+		"US": decimal.LocaleUS,
+		"DE": decimal.LocaleDE,
+		"FR": decimal.LocaleFR,
+		"CH": decimal.LocaleCH,
+		"IN": decimal.LocaleIN,
+	}
+	for test, expected := range testCases {
+		d, err := decimal.ParseLocale(test.numberStr, locales[test.locale])
+		if expected.err == nil {
+			if err != nil || d != expected.decimal {
+				t.Fatalf("ParseLocale(%q, %s) expected %v, instead got (%v, %v)", test.numberStr, test.locale, expected.decimal, d, err)
+			}
+			continue
+		}
+		var parseError *decimal.ParseError
+		if !errors.As(err, &parseError) || !errors.Is(err, expected.err) || parseError.Offset != expected.offset || parseError.Input != test.numberStr {
+			t.Fatalf("ParseLocale(%q, %s) expected %v at offset %d, instead got (%v, %v)", test.numberStr, test.locale, expected.err, expected.offset, d, err)
+		}
+	}
+	// The zero locale uses '.' and no grouping separators
+	if d, err := decimal.ParseLocale("1234.5", decimal.Locale{}); err != nil || d != (decimal.Decimal{Sign: true, Value: 12345, PowerOfTen: -1}) {
+		t.Fatalf("ParseLocale(\"1234.5\", Locale{}) expected 1234.5, instead got (%v, %v)", d, err)
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	accounting := decimal.Locale{Decimal: ",", Group: []string{" "}, Negative: "(n)", Percent: "%n"}
+	testCases := map[struct {
+		number string
+		locale string
+		opts   decimal.FormatOptions
+	}]string{
+		{"-1234567.891", "de-DE", decimal.FormatOptions{FractionDigits: 2}}:                                              "-1.234.567,89",
+		{"1234567.891", "en-IN", decimal.FormatOptions{FractionDigits: 2}}:                                               "12,34,567.89",
+		{"-1234567.891", "en-US", decimal.FormatOptions{}}:                                                               "-1,234,567.891",
+		{"1234567.891", "en-IN", decimal.FormatOptions{FractionDigits: -5}}:                                              "12,34,568",
+		{"1234567.891", "fr-FR", decimal.FormatOptions{FractionDigits: 2}}:                                               "1\u202f234\u202f567,89",
+		{"1234567.891", "en-US", decimal.FormatOptions{}}:                                                                "1,234,567.891",
+		{"1234567.891", "de-CH", decimal.FormatOptions{FractionDigits: decimal.NoFractionDigits}}:                        "1\u2019234\u2019568",
+		{"123", "en-US", decimal.FormatOptions{FractionDigits: 2}}:                                                       "123.00",
+		{"1234", "en-IN", decimal.FormatOptions{}}:                                                                       "1,234",
+		{"123456", "en-IN", decimal.FormatOptions{}}:                                                                     "1,23,456",
+		{"0.5", "it-IT", decimal.FormatOptions{}}:                                                                        "0,5",
+		{"2.5", "en-US", decimal.FormatOptions{FractionDigits: decimal.NoFractionDigits}}:                                "2",
+		{"2.5", "en-US", decimal.FormatOptions{FractionDigits: decimal.NoFractionDigits, Rounding: decimal.RoundHalfUp}}: "3",
+		{"-0.001", "en-US", decimal.FormatOptions{FractionDigits: 2}}:                                                    "0.00",
+		{"-0.001", "en-US", decimal.FormatOptions{FractionDigits: 2, Rounding: decimal.RoundUp}}:                         "-0.01",
+		{"-0", "en-US", decimal.FormatOptions{}}:                                                                         "0",
+		{"1.5e6", "de-DE", decimal.FormatOptions{}}:                                                                      "1.500.000",
+		{"0.1234", "fr-FR", decimal.FormatOptions{FractionDigits: 1, Percent: true}}:                                     "12,3\u202f%",
+		{"-0.1234", "de-DE", decimal.FormatOptions{Percent: true}}:                                                       "-12,34\u00a0%",
+		{"12.5", "en-US", decimal.FormatOptions{FractionDigits: decimal.NoFractionDigits, Percent: true}}:                "1,250%",
+		{"-1234.5", "accounting", decimal.FormatOptions{FractionDigits: 2}}:                                              "(1 234,50)",
+		{"-0.125", "accounting", decimal.FormatOptions{FractionDigits: 1, Percent: true}}:                                "(%12,5)",
+		{"1234.5", "custom", decimal.FormatOptions{}}:                                                                    "1234.5",
+		{"-1e1234", "de-DE", decimal.FormatOptions{FractionDigits: 2}}:                                                   "-1e1234",
+		{"1e-1234", "de-DE", decimal.FormatOptions{}}:                                                                    "1e-1234",
+		{"NaN", "de-DE", decimal.FormatOptions{FractionDigits: 2}}:                                                       "NaN",
+		{"-Inf", "de-DE", decimal.FormatOptions{FractionDigits: 2, Percent: true}}:                                       "-Inf",
+	}
+	locales := map[string]decimal.Locale{
+		"accounting": accounting,
+		"custom":     {},
+	}
+	for test, expected := range testCases {
+		number := mustParse(t, test.number)
+		locale, ok := decimal.Locales[test.locale]
+		if !ok {
+			locale = locales[test.locale]
+		}
+		if actual := decimal.FormatLocale(number, locale, test.opts); actual != expected {
+			t.Fatalf("FormatLocale(%v, %s, %+v) expected %q, instead got %q", number, test.locale, test.opts, expected, actual)
+		}
+	}
+	// Percentages that overflow
+	if actual := decimal.FormatLocale(decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, decimal.LocaleUS, decimal.FormatOptions{Percent: true}); actual != "Inf" {
+		t.Fatalf("FormatLocale expected %q for an overflowing percentage, instead got %q", "Inf", actual)
+	}
+}
+
+func BenchmarkParseLocale(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		decimal.ParseLocale("-1.234.567,89", decimal.LocaleDE)
+	}
+}
+
+func BenchmarkFormatLocale(b *testing.B) {
+	number := decimal.Decimal{Sign: false, Value: 123456789, PowerOfTen: -2}
+	for i := 0; i < b.N; i++ {
+		decimal.FormatLocale(number, decimal.LocaleDE, decimal.FormatOptions{FractionDigits: 2})
+	}
+}
+
+func FuzzParseLocale(f *testing.F) {
+	f.Add(true, uint64(123456789), uint8(2), true, uint8(0))
+	f.Add(false, uint64(1000), uint8(0), false, uint8(1))
+	f.Add(true, uint64(18446744073709551615), uint8(19), true, uint8(4))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, scale uint8, grouped bool, localeIndex uint8) {
+		locale := []decimal.Locale{decimal.LocaleUS, decimal.LocaleDE, decimal.LocaleFR, decimal.LocaleCH, decimal.LocaleIN}[localeIndex%5]
+		// Write the number in the locale
+		digits := strconv.FormatUint(value, 10)
+		whole, fraction := digits, ""
+		if int(scale%20) < len(digits) && scale%20 > 0 {
+			whole, fraction = digits[:len(digits)-int(scale%20)], digits[len(digits)-int(scale%20):]
+		}
+		if grouped {
+			groups := []string{}
+			for j := 0; len(whole) > 0; j++ {
+				size := locale.Grouping[len(locale.Grouping)-1]
+				if j < len(locale.Grouping) {
+					size = locale.Grouping[j]
+				}
+				if size > len(whole) {
+					size = len(whole)
+				}
+				groups = append([]string{whole[len(whole)-size:]}, groups...)
+				whole = whole[:len(whole)-size]
+			}
+			whole = strings.Join(groups, locale.Group[0])
+		}
+		numberStr := whole
+		if fraction != "" {
+			numberStr += locale.Decimal + fraction
+		}
+		if !sign {
+			numberStr = "-" + numberStr
+		}
+		// It parses like the canonical number
+		canonical := strings.ReplaceAll(strings.ReplaceAll(numberStr, locale.Group[0], ""), locale.Decimal, ".")
+		expected, err := decimal.ParseStrict(canonical)
+		if err != nil {
+			t.Fatalf("ParseStrict(%q) returned an unexpected error: %v", canonical, err)
+		}
+		if d, err := decimal.ParseLocale(numberStr, locale); err != nil || d != expected {
+			t.Fatalf("ParseLocale(%q) expected %v, instead got (%v, %v)", numberStr, expected, d, err)
+		}
+		// Removing a grouping separator makes the grouping inconsistent
+		if index := strings.Index(numberStr, locale.Group[0]); index >= 0 && strings.Count(numberStr, locale.Group[0]) > 1 {
+			broken := numberStr[:index] + numberStr[index+len(locale.Group[0]):]
+			if _, err := decimal.ParseLocale(broken, locale); !errors.Is(err, decimal.ErrorParsingGrouping) {
+				t.Fatalf("ParseLocale(%q) expected ErrorParsingGrouping, instead got %v", broken, err)
+			}
+		}
+	})
+}
+
+func FuzzFormatLocale(f *testing.F) {
+	f.Add(false, uint64(123456789), int16(-2), int8(2), uint8(0))
+	f.Add(true, uint64(1000), int16(3), int8(-1), uint8(1))
+	f.Add(true, uint64(18446744073709551615), int16(-19), int8(5), uint8(4))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int16, fractionDigits int8, localeIndex uint8) {
+		locale := []decimal.Locale{decimal.LocaleUS, decimal.LocaleDE, decimal.LocaleFR, decimal.LocaleCH, decimal.LocaleIN}[localeIndex%5]
+		number := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		opts := decimal.FormatOptions{FractionDigits: int(fractionDigits)}
+		formatted := decimal.FormatLocale(number, locale, opts)
+		// It parses back to the rounded number
+		expected := number.Clone()
+		if opts.FractionDigits > 0 {
+			expected.Round(int64(opts.FractionDigits), opts.Rounding)
+		} else if opts.FractionDigits < 0 {
+			expected.Round(0, opts.Rounding)
+		}
+		parsed, err := decimal.ParseLocale(formatted, locale)
+		if err != nil || !parsed.Equals(expected) {
+			t.Fatalf("FormatLocale(%v) returned %q, which parses as (%v, %v) instead of %v", number, formatted, parsed, err, expected)
+		}
+		// With the expected number of fraction digits
+		if decimalSeparator := strings.LastIndex(formatted, locale.Decimal); opts.FractionDigits > 0 && strings.Count(formatted, "e") == 0 &&
+			(decimalSeparator < 0 || len(formatted)-decimalSeparator-len(locale.Decimal) != opts.FractionDigits) {
+			t.Fatalf("FormatLocale(%v) returned %q, expected %d fraction digits", number, formatted, opts.FractionDigits)
+		}
+	})
+}
@@ -1,9 +1,131 @@
 package decimal
 
-// Returns true if the two decimals are equal
+// Returns true if the two decimals are equal.
+// Infinities are equal if they have the same sign, and (unlike floats) NaN is equal to NaN.
 func (d Decimal) Equals(x Decimal) bool {
+	// Special case: NaN and infinities
+	if !d.IsFinite() || !x.IsFinite() {
+		return (d.IsNaN() && x.IsNaN()) || (d.IsInf() && x.IsInf() && d.Sign == x.Sign)
+	}
 	d.Compress()
 	x.Compress()
 	return (d.Value == 0 && x.Value == 0) ||
 		(d.Sign == x.Sign && d.Value == x.Value && d.PowerOfTen == x.PowerOfTen)
 }
+
+// Compares this decimal to x and returns:
+//   - -1 if d < x
+//   - 0 if d == x (zero is equal to zero regardless of its sign)
+//   - +1 if d > x
+//
+// Like cmp.Compare does for floats, NaN is considered less than any other number and equal to NaN.
+// Can be used to sort decimals, for example with slices.SortFunc
+func (d Decimal) Cmp(x Decimal) int {
+	// Special case: NaN and infinities
+	if !d.IsFinite() || !x.IsFinite() {
+		switch dRank, xRank := d.rank(), x.rank(); {
+		case dRank < xRank:
+			return -1
+		case dRank > xRank:
+			return 1
+		}
+		return 0
+	}
+	// Special case: zero
+	if d.IsZero() || x.IsZero() {
+		switch {
+		case d.IsZero() && x.IsZero():
+			return 0
+		case d.IsZero() && x.Sign, x.IsZero() && !d.Sign:
+			return -1
+		default:
+			return 1
+		}
+	}
+	// Different signs
+	if d.Sign != x.Sign {
+		if d.Sign {
+			return 1
+		}
+		return -1
+	}
+	// Same sign, compare the absolute values
+	cmp := d.cmpAbs(x)
+	if !d.Sign {
+		return -cmp
+	}
+	return cmp
+}
+
+// Returns the position of this number in the order NaN < -Inf < finite numbers < +Inf
+func (d Decimal) rank() int {
+	switch {
+	case d.IsNaN():
+		return 0
+	case d.IsInf() && !d.Sign:
+		return 1
+	case d.IsInf():
+		return 3
+	}
+	return 2
+}
+
+// Compares the absolute values of two non-zero decimals
+func (d Decimal) cmpAbs(x Decimal) int {
+	// Once expanded a larger power of ten always means a larger number
+	// unless the expansion was stopped early by the power of ten limit
+	// in which case the other number can't have a smaller power of ten
+	d.Expand()
+	x.Expand()
+	switch {
+	case d.PowerOfTen < x.PowerOfTen:
+		return -1
+	case d.PowerOfTen > x.PowerOfTen:
+		return 1
+	case d.Value < x.Value:
+		return -1
+	case d.Value > x.Value:
+		return 1
+	}
+	return 0
+}
+
+// Returns true if d < x
+func (d Decimal) Less(x Decimal) bool {
+	return d.Cmp(x) < 0
+}
+
+// Returns true if d <= x
+func (d Decimal) LessOrEqual(x Decimal) bool {
+	return d.Cmp(x) <= 0
+}
+
+// Returns true if d > x
+func (d Decimal) Greater(x Decimal) bool {
+	return d.Cmp(x) > 0
+}
+
+// Returns true if d >= x
+func (d Decimal) GreaterOrEqual(x Decimal) bool {
+	return d.Cmp(x) >= 0
+}
+
+// Returns the smallest of the given decimals
+func Min(first Decimal, rest ...Decimal) Decimal {
+	for _, x := range rest {
+		if x.Less(first) {
+			first = x
+		}
+	}
+	return first
+}
+
+// Returns the largest of the given decimals
+func Max(first Decimal, rest ...Decimal) Decimal {
+	for _, x := range rest {
+		if x.Greater(first) {
+			first = x
+		}
+	}
+	return first
+}
@@ -1,6 +1,8 @@
 package decimal_test
 
 import (
+	"math"
+	"slices"
 	"testing"
 
 	"github.com/stefanovazzocell/GoDecimal/decimal"
@@ -10,11 +12,17 @@ func TestLogic(t *testing.T) {
 	testCases := map[struct{ x, y decimal.Decimal }]struct {
 		equals bool
 	}{
-		{decimal.Decimal{}, decimal.Decimal{}}:                                  {equals: true},
-		{decimal.Decimal{Value: 1}, decimal.Decimal{}}:                          {equals: false},
-		{decimal.Decimal{PowerOfTen: 1}, decimal.Decimal{}}:                     {equals: true},
-		{decimal.Decimal{}, decimal.Decimal{Sign: true}}:                        {equals: true},
-		{decimal.Decimal{Value: 100}, decimal.Decimal{Value: 1, PowerOfTen: 2}}: {equals: true},
+		{decimal.Decimal{}, decimal.Decimal{}}:                                                             {equals: true},
+		{decimal.Decimal{Value: 1}, decimal.Decimal{}}:                                                     {equals: false},
+		{decimal.Decimal{PowerOfTen: 1}, decimal.Decimal{}}:                                                {equals: true},
+		{decimal.Decimal{}, decimal.Decimal{Sign: true}}:                                                   {equals: true},
+		{decimal.Decimal{Value: 100}, decimal.Decimal{Value: 1, PowerOfTen: 2}}:                            {equals: true},
+		{decimal.NaN(), decimal.NaN()}:                                                                     {equals: true},
+		{decimal.NaN(), decimal.Decimal{}}:                                                                 {equals: false},
+		{decimal.Inf(true), decimal.Inf(true)}:                                                             {equals: true},
+		{decimal.Inf(true), decimal.Inf(false)}:                                                            {equals: false},
+		{decimal.Inf(false), decimal.NaN()}:                                                                {equals: false},
+		{decimal.Inf(true), decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64}}: {equals: false},
 	}
 
 	for test, expected := range testCases {
@@ -24,3 +32,108 @@ func TestLogic(t *testing.T) {
 		}
 	}
 }
+
+func TestCmp(t *testing.T) {
+	testCases := map[struct{ x, y decimal.Decimal }]int{
+		{decimal.Decimal{}, decimal.Decimal{}}:                                                                                                                      0,
+		{decimal.Decimal{Sign: false}, decimal.Decimal{Sign: true, PowerOfTen: 5}}:                                                                                  0,
+		{decimal.Decimal{Sign: true, Value: 1}, decimal.Decimal{}}:                                                                                                  1,
+		{decimal.Decimal{Sign: false, Value: 1}, decimal.Decimal{}}:                                                                                                 -1,
+		{decimal.Decimal{}, decimal.Decimal{Sign: true, Value: 1}}:                                                                                                  -1,
+		{decimal.Decimal{}, decimal.Decimal{Sign: false, Value: 1}}:                                                                                                 1,
+		{decimal.Decimal{Sign: true, Value: 100}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 2}}:                                                             0,
+		{decimal.Decimal{Sign: true, Value: 1}, decimal.Decimal{Sign: false, Value: 1}}:                                                                             1,
+		{decimal.Decimal{Sign: false, Value: 1}, decimal.Decimal{Sign: true, Value: 1}}:                                                                             -1,
+		{decimal.Decimal{Sign: true, Value: 9, PowerOfTen: -1}, decimal.Decimal{Sign: true, Value: 1}}:                                                              -1,
+		{decimal.Decimal{Sign: false, Value: 9, PowerOfTen: -1}, decimal.Decimal{Sign: false, Value: 1}}:                                                            1,
+		{decimal.Decimal{Sign: true, Value: math.MaxUint64}, decimal.Decimal{Sign: true, Value: 2, PowerOfTen: 19}}:                                                 -1,
+		{decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, decimal.Decimal{Sign: true, Value: 9999999999999999999, PowerOfTen: math.MaxInt64 - 19}}: 1,
+		{decimal.Decimal{Sign: true, Value: 2, PowerOfTen: math.MinInt64}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MinInt64 + 1}}:                    -1,
+		{decimal.Decimal{Sign: true, Value: 20, PowerOfTen: math.MinInt64}, decimal.Decimal{Sign: true, Value: 2, PowerOfTen: math.MinInt64 + 1}}:                   0,
+		{decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MinInt64}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MinInt64 + 20}}:      -1,
+		{decimal.NaN(), decimal.NaN()}:      0,
+		{decimal.NaN(), decimal.Inf(false)}: -1,
+		{decimal.Inf(false), decimal.Decimal{Sign: false, Value: math.MaxUint64, PowerOfTen: math.MaxInt64}}: -1,
+		{decimal.Inf(true), decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: math.MaxInt64}}:   1,
+		{decimal.Inf(true), decimal.Inf(true)}:  0,
+		{decimal.Inf(false), decimal.Inf(true)}: -1,
+	}
+	for test, expected := range testCases {
+		if actual := test.x.Cmp(test.y); actual != expected {
+			t.Fatalf("%v.Cmp(%v) returned %d, but expected %d", test.x, test.y, actual, expected)
+		}
+		if actual := test.y.Cmp(test.x); actual != -expected {
+			t.Fatalf("%v.Cmp(%v) returned %d, but expected %d", test.y, test.x, actual, -expected)
+		}
+		if test.x.Less(test.y) != (expected < 0) ||
+			test.x.LessOrEqual(test.y) != (expected <= 0) ||
+			test.x.Greater(test.y) != (expected > 0) ||
+			test.x.GreaterOrEqual(test.y) != (expected >= 0) {
+			t.Fatalf("Comparison helpers for %v and %v disagree with Cmp %d", test.x, test.y, expected)
+		}
+		if test.x.Equals(test.y) != (expected == 0) {
+			t.Fatalf("Equals for %v and %v disagrees with Cmp %d", test.x, test.y, expected)
+		}
+	}
+}
+
+func TestSortMinMax(t *testing.T) {
+	numbers := []decimal.Decimal{
+		{Sign: true, Value: 15, PowerOfTen: -1},
+		{Sign: false, Value: 3},
+		{Sign: true, Value: 1, PowerOfTen: 1},
+		{Sign: false, Value: 0},
+		{Sign: false, Value: 25, PowerOfTen: -1},
+		{Sign: true, Value: 2},
+	}
+	expected := []string{"-3", "-2.5", "0", "1.5", "2", "10"}
+	slices.SortFunc(numbers, decimal.Decimal.Cmp)
+	for i, number := range numbers {
+		if actual := number.FormatText(false, false, 0); actual != expected[i] && !(number.IsZero() && expected[i] == "0") {
+			t.Fatalf("Expected %q at position %d after sorting, instead got %q", expected[i], i, actual)
+		}
+	}
+	if min := decimal.Min(numbers[3], numbers...); !min.Equals(numbers[0]) {
+		t.Fatalf("Expected Min to return %v, instead got %v", numbers[0], min)
+	}
+	if max := decimal.Max(numbers[3], numbers...); !max.Equals(numbers[len(numbers)-1]) {
+		t.Fatalf("Expected Max to return %v, instead got %v", numbers[len(numbers)-1], max)
+	}
+	if single := decimal.Min(numbers[2]); !single.Equals(numbers[2]) {
+		t.Fatalf("Expected Min of a single value to return it, instead got %v", single)
+	}
+}
+
+func BenchmarkCmp(b *testing.B) {
+	x := decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -4}
+	y := decimal.Decimal{Sign: true, Value: 12345678, PowerOfTen: -3}
+	for i := 0; i < b.N; i++ {
+		_ = x.Cmp(y)
+	}
+}
+
+func FuzzCmp(f *testing.F) {
+	seeds := []struct {
+		x, y decimal.Decimal
+	}{
+		{decimal.Decimal{}, decimal.Decimal{}},
+		{decimal.Decimal{Sign: true, Value: 100}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 2}},
+		{decimal.Decimal{Sign: false, Value: 15, PowerOfTen: -1}, decimal.Decimal{Sign: true, Value: 2}},
+		{decimal.Decimal{Sign: true, Value: math.MaxUint64, PowerOfTen: -40}, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: -21}},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.x.Sign, seed.x.Value, int16(seed.x.PowerOfTen), seed.y.Sign, seed.y.Value, int16(seed.y.PowerOfTen))
+	}
+	f.Fuzz(func(t *testing.T, xSign bool, xValue uint64, xPower int16, ySign bool, yValue uint64, yPower int16) {
+		// NOTE: We limit the power of ten to keep the reference big.Rat reasonably small
+		x := decimal.Decimal{Sign: xSign, Value: xValue, PowerOfTen: int64(xPower)}
+		y := decimal.Decimal{Sign: ySign, Value: yValue, PowerOfTen: int64(yPower)}
+		expected := toRat(x).Cmp(toRat(y))
+		if actual := x.Cmp(y); actual != expected {
+			t.Fatalf("%v.Cmp(%v) returned %d, but big.Rat says %d", x, y, actual, expected)
+		}
+		if x.Equals(y) != (expected == 0) {
+			t.Fatalf("%v.Equals(%v) disagrees with big.Rat", x, y)
+		}
+	})
+}
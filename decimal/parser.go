@@ -3,6 +3,7 @@ package decimal
 import (
 	"errors"
 	"math"
+	"strings"
 )
 
 const (
@@ -23,6 +24,8 @@ var (
 //  2. Will ignore any unrecognized character.
 //  3. Parses anything after the first 'e' as the exponential.
 //  4. If the number ends with '%' the number will be parsed as a percentage.
+//  5. "NaN", "Inf" and "Infinity" (ignoring case, optionally preceded by a sign) parse as special values.
+//  6. Ignores anything after the first '/', use ParseFraction or ParseRational to parse fractions.
 //
 // Examples:
 //  1. "-1!23.45e-23" parses as -12345 * 10 ^ -25
@@ -32,143 +35,180 @@ var (
 //  5. A string composed of 100 digits of "9" parses as 9999999999999999999 * 10 ^ (100 - 19)
 //  6. "9." followed by a string of 100 digits of "9" parses as 9999999999999999999 * 10 ^ -(100  - 18)
 func ParseString(numberStr string) (Decimal, error) {
+	// Special case: NaN and infinities
+	if special, ok := parseSpecial(numberStr); ok {
+		return special, nil
+	}
 	// Setup defaults
 	decimal := Decimal{
 		Sign:       true,
 		Value:      0,
 		PowerOfTen: 0,
 	}
+	// Value (whole + decimal part)
+	digits := uint64(0)
+	sign, percentage, exponent := scanNumber(numberStr, func(digit uint8, fraction bool) {
+		if digits == 0 {
+			// It's the first digit, initialize the number (leading zeroes don't count)
+			if fraction {
+				decimal.PowerOfTen--
+			}
+			if digit != 0 {
+				digits++
+			}
+			decimal.Value = uint64(digit)
+		} else if digits > digitsCutoff {
+			// We can't add any more number to the value (max precision)
+			if !fraction {
+				/*
+					Note: number.PowerOfTen can never overflow.
+					len(numberStr) is an integer and therefore cannot store enough digits to overflow
+					number.PowerOfTen no matter how many digits are "soft-overflown" there.
+				*/
+				decimal.PowerOfTen++
+			}
+		} else if digits == digitsCutoff {
+			// Almost max precision, add digit if possible
+			digits++
+			if overflow_multiplication(decimal.Value, 10) || overflow_sum(decimal.Value*10, uint64(digit)) {
+				// Precision-loss overflow
+				if !fraction {
+					decimal.PowerOfTen++
+				}
+				return
+			}
+			if fraction {
+				decimal.PowerOfTen--
+			}
+			decimal.Value = (decimal.Value * 10) + uint64(digit)
+		} else {
+			// Can add digit to value
+			digits++
+			if fraction {
+				decimal.PowerOfTen--
+			}
+			decimal.Value = (decimal.Value * 10) + uint64(digit)
+		}
+	})
+	decimal.Sign = sign
+	if percentage {
+		// 1% == 0.01 == 1e-2
+		decimal.PowerOfTen -= 2
+	}
+	// Power of 10 ('E'/'e')
+	if len(exponent) == 0 || decimal.Value == 0 {
+		return decimal, nil
+	}
+	powerOfTen, err := parseExponent(exponent)
+	if err != nil {
+		return Decimal{}, err
+	}
+	// Merge powers of 10
+	if powerOfTen < 0 {
+		if decimal.PowerOfTen < 0 && powerOfTen < math.MinInt64-decimal.PowerOfTen {
+			// Overflow!
+			return Decimal{}, ErrorParsingOverflow
+		}
+	} else if 0 < decimal.PowerOfTen && math.MaxInt64-decimal.PowerOfTen < powerOfTen {
+		// Overflow!
+		return Decimal{}, ErrorParsingOverflow
+	}
+	decimal.PowerOfTen += powerOfTen
+	return decimal, nil
+}
+
+// Parses "NaN", "Inf" or "Infinity" (ignoring case) optionally preceded by a sign.
+// Returns false if the string is not one of them.
+func parseSpecial(numberStr string) (Decimal, bool) {
+	sign := true
+	if len(numberStr) > 0 && (numberStr[0] == '+' || numberStr[0] == '-') {
+		sign = numberStr[0] == '+'
+		numberStr = numberStr[1:]
+	}
+	switch {
+	case strings.EqualFold(numberStr, "NaN"):
+		return NaN(), true
+	case strings.EqualFold(numberStr, "Inf") || strings.EqualFold(numberStr, "Infinity"):
+		return Inf(sign), true
+	}
+	return Decimal{}, false
+}
+
+// Scans a number string following the rules described in ParseString.
+// Calls `digit` for every digit of the number, with `fraction` set for the ones after the decimal point.
+// Returns the sign of the number, whether it's a percentage, and what follows 'e' (if anything).
+func scanNumber(numberStr string, digit func(digit uint8, fraction bool)) (sign bool, percentage bool, exponent string) {
+	sign = true
 	// Special case: 0
 	if len(numberStr) == 0 {
-		return decimal, nil
+		return sign, false, ""
 	}
 	// Check sign
 	if numberStr[0] == '+' {
 		numberStr = numberStr[1:]
 	} else if numberStr[0] == '-' {
-		decimal.Sign = false
+		sign = false
 		numberStr = numberStr[1:]
 	}
 	// Check if percentage
 	if len(numberStr) > 1 && numberStr[len(numberStr)-1] == '%' {
-		// 1% == 0.01 == 1e-2
-		decimal.PowerOfTen = -2
+		percentage = true
 		numberStr = numberStr[:len(numberStr)-1]
 	}
 	// Value (whole + decimal part)
-	digits := uint64(0)
+	fraction := false
 	for i := 0; i < len(numberStr); i++ {
 		if '0' <= numberStr[i] && numberStr[i] <= '9' {
-			if digits == 0 {
-				// It's the first digit, initialize the number
-				if numberStr[i] != '0' {
-					digits++
-				}
-				decimal.Value = uint64(numberStr[i] - '0')
-			} else if digits > digitsCutoff {
-				// We can't add any more number to the value (max precision)
-				/*
-					Note: number.PowerOfTen can never overflow.
-					len(numberStr) is an integer and therefore cannot store enough digits to overflow
-					number.PowerOfTen no matter how many digits are "soft-overflown" there.
-				*/
-				decimal.PowerOfTen++
-			} else if digits == digitsCutoff {
-				// Almost max precision, add digit if possible
-				digits++
-				if overflow_multiplication(decimal.Value, 10) {
-					// Precision-loss overflow
-					decimal.PowerOfTen++
-					continue
-				}
-				decimal.Value = (decimal.Value * 10) + uint64(numberStr[i]-'0')
-			} else {
-				// Can add digit to value
-				digits++
-				decimal.Value = (decimal.Value * 10) + uint64(numberStr[i]-'0')
-			}
+			digit(numberStr[i]-'0', fraction)
 		} else if numberStr[i] == '.' {
 			// Moving to decimal part
-			for j := i + 1; j < len(numberStr); j++ {
-				if '0' <= numberStr[j] && numberStr[j] <= '9' {
-					if digits == 0 {
-						// It's the first digit, initialize the number
-						digits++
-						decimal.PowerOfTen--
-						decimal.Value = uint64(numberStr[j] - '0')
-					} else if digits > digitsCutoff {
-						// We can't add any more number to the value (max precision)
-						continue
-					} else if digits == digitsCutoff {
-						// Almost max precision, add digit if possible
-						digits++
-						if overflow_multiplication(decimal.Value, 10) {
-							// Precision-loss overflow
-							continue
-						}
-						decimal.PowerOfTen--
-						decimal.Value = (decimal.Value * 10) + uint64(numberStr[j]-'0')
-					} else {
-						digits++
-						decimal.PowerOfTen--
-						decimal.Value = (decimal.Value * 10) + uint64(numberStr[j]-'0')
-					}
-				} else if numberStr[j] == '/' || numberStr[j] == 'e' || numberStr[j] == 'E' {
-					numberStr = numberStr[j:]
-					break
-				}
-			}
-			break
+			fraction = true
 		} else if numberStr[i] == '/' || numberStr[i] == 'e' || numberStr[i] == 'E' {
 			numberStr = numberStr[i:]
 			break
 		}
 	}
 	// Power of 10 ('E'/'e')
-	if len(numberStr) > 1 && (numberStr[0] == 'e' || numberStr[0] == 'E') && decimal.Value != 0 {
-		// Parse positiveE
-		numberStr = numberStr[1:]
-		positiveE := true
-		if len(numberStr) > 0 && numberStr[0] == '+' {
-			numberStr = numberStr[1:]
-		} else if len(numberStr) > 0 && numberStr[0] == '-' {
-			positiveE = false
-			numberStr = numberStr[1:]
-		}
-		// Read number
-		powerOfTen := int64(0)
-		first := true
-		for i := 0; i < len(numberStr); i++ {
-			if '0' <= numberStr[i] && numberStr[i] <= '9' {
-				if first {
-					if numberStr[i] == '0' {
-						continue
-					}
-					first = false
-					powerOfTen = int64(numberStr[i] - '0')
+	if len(numberStr) > 1 && (numberStr[0] == 'e' || numberStr[0] == 'E') {
+		exponent = numberStr[1:]
+	}
+	return sign, percentage, exponent
+}
+
+// Parses the power of ten following 'e' in a number string, ignoring any unknown characters
+func parseExponent(exponent string) (int64, error) {
+	// Parse positiveE
+	positiveE := true
+	if len(exponent) > 0 && exponent[0] == '+' {
+		exponent = exponent[1:]
+	} else if len(exponent) > 0 && exponent[0] == '-' {
+		positiveE = false
+		exponent = exponent[1:]
+	}
+	// Read number
+	powerOfTen := int64(0)
+	first := true
+	for i := 0; i < len(exponent); i++ {
+		if '0' <= exponent[i] && exponent[i] <= '9' {
+			if first {
+				if exponent[i] == '0' {
 					continue
 				}
-				powerOfTen = powerOfTen*10 + int64(numberStr[i]-'0')
-				if powerOfTen < 0 {
-					// Overflow!
-					return Decimal{}, ErrorParsingOverflow
-				}
+				first = false
+				powerOfTen = int64(exponent[i] - '0')
+				continue
 			}
-		}
-		// Merge powers of 10
-		if !positiveE {
-			powerOfTen *= -1
-			if decimal.PowerOfTen < 0 && powerOfTen < math.MinInt64-decimal.PowerOfTen {
+			powerOfTen = powerOfTen*10 + int64(exponent[i]-'0')
+			if powerOfTen < 0 {
 				// Overflow!
-				return Decimal{}, ErrorParsingOverflow
+				return 0, ErrorParsingOverflow
 			}
-		} else if 0 < decimal.PowerOfTen && math.MaxInt64-decimal.PowerOfTen < powerOfTen {
-			// Overflow!
-			return Decimal{}, ErrorParsingOverflow
 		}
-		decimal.PowerOfTen += powerOfTen
 	}
-	return decimal, nil
+	if !positiveE {
+		powerOfTen *= -1
+	}
+	return powerOfTen, nil
 }
 
 // Implementation of the TextUnmarshaler interface using the ParseString function
@@ -8,6 +8,16 @@ import (
 	"github.com/stefanovazzocell/GoDecimal/decimal"
 )
 
+// Parses a Decimal or fails the test
+func mustParse(t testing.TB, numberStr string) decimal.Decimal {
+	t.Helper()
+	number, err := decimal.ParseString(numberStr)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	return number
+}
+
 func TestParseString(t *testing.T) {
 	testCases := map[string]struct {
 		decimal  decimal.Decimal
@@ -30,12 +40,21 @@ func TestParseString(t *testing.T) {
 		"-1!23.45e-23%":                {decimal.Decimal{Sign: false, Value: 12345, PowerOfTen: -27}, false},
 		"23.e":                         {decimal.Decimal{Sign: true, Value: 23, PowerOfTen: 0}, false},
 		"90000000000000000000":         {decimal.Decimal{Sign: true, Value: 9000000000000000000, PowerOfTen: 1}, false},
+		"18446744073709551619":         {decimal.Decimal{Sign: true, Value: 1844674407370955161, PowerOfTen: 1}, false},
 		"-123456789.0000123456789E123": {decimal.Decimal{Sign: false, Value: 12345678900001234567, PowerOfTen: 112}, false},
 		"+987654321.0000987654321e123": {decimal.Decimal{Sign: true, Value: 9876543210000987654, PowerOfTen: 113}, false},
 		"1e+" + strconv.FormatInt(math.MaxInt64, 10) + "0":                 {decimal.Decimal{}, true},
 		"1e-" + strconv.FormatInt(math.MinInt64, 10) + "0":                 {decimal.Decimal{}, true},
 		"1000000000000000000000e" + strconv.FormatInt(math.MaxInt64-1, 10): {decimal.Decimal{}, true},
 		"0.01e" + strconv.FormatInt(math.MinInt64+1, 10):                   {decimal.Decimal{}, true},
+		"0.003162277660168379332":                                          {decimal.Decimal{Sign: true, Value: 3162277660168379332, PowerOfTen: -21}, false},
+		"0.0000000000000000000001":                                         {decimal.Decimal{Sign: true, Value: 1, PowerOfTen: -22}, false},
+		"NaN":                                                              {decimal.NaN(), false},
+		"-nan":                                                             {decimal.NaN(), false},
+		"Inf":                                                              {decimal.Inf(true), false},
+		"+inf":                                                             {decimal.Inf(true), false},
+		"-Infinity":                                                        {decimal.Inf(false), false},
+		"Infinite":                                                         {decimal.Decimal{Sign: true}, false},
 	}
 	for numberStr, testExpected := range testCases {
 		// Prepare a readable string for error messages
@@ -53,9 +72,7 @@ func TestParseString(t *testing.T) {
 			t.Fatalf("%q returned an unexpected error: %v", readable, err)
 		}
 		// Check number
-		if actualNumber.Sign != testExpected.decimal.Sign ||
-			actualNumber.Value != testExpected.decimal.Value ||
-			actualNumber.PowerOfTen != testExpected.decimal.PowerOfTen {
+		if actualNumber != testExpected.decimal {
 			t.Fatalf("%q expected:\n%v\ninstead got:\n%v", readable, testExpected.decimal, actualNumber)
 		}
 		// Reset and try with UnmarshalText
@@ -69,9 +86,7 @@ func TestParseString(t *testing.T) {
 			t.Fatalf("%q returned an unexpected error for UnmarshalText: %v", readable, err)
 		}
 		// Check number
-		if actualNumber.Sign != testExpected.decimal.Sign ||
-			actualNumber.Value != testExpected.decimal.Value ||
-			actualNumber.PowerOfTen != testExpected.decimal.PowerOfTen {
+		if actualNumber != testExpected.decimal {
 			t.Fatalf("%q expected:\n%v\nfor UnmarshalText, instead got:\n%v", readable, testExpected.decimal, actualNumber)
 		}
 		// Check that UnmarshalText handles nil values
@@ -0,0 +1,272 @@
+package decimal
+
+import "math/big"
+
+// Returns x^n rounded with RoundHalfEven to as many digits as fit in a Decimal.
+// A negative n returns 1 / x^-n, and x^0 is 1 for any x except NaN.
+// If the operation overflows/underflows, returns the closest Decimal alongside ErrOverflow/ErrUnderflow.
+// Returns ±infinity and ErrDivisionByZero if x is zero and n is negative
+//
+// Example:
+//
+//	// 1000 at 5% for 10 years
+//	growth, err := decimal.PowInt(decimal.Decimal{Sign: true, Value: 105, PowerOfTen: -2}, 10)
+//	total, err := decimal.Mult(decimal.DecimalFromInt(1000), growth)
+func PowInt(x Decimal, n int64) (Decimal, error) {
+	result, cond := powInt(x, n, Context{})
+	return result, conditionError(cond)
+}
+
+// Returns the square root of x rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal.
+// Returns NaN and ErrIndeterminate if x is negative
+func Sqrt(x Decimal, precision int) (Decimal, error) {
+	return NthRoot(x, 2, precision)
+}
+
+// Returns the n-th root of x rounded with RoundHalfEven to `precision` significant digits.
+// A precision <= 0 keeps as many digits as fit in a Decimal. The cost grows with precision, and with n up to 64.
+// Returns NaN and ErrIndeterminate if n is not positive, or if x is negative and n is even
+func NthRoot(x Decimal, n int64, precision int) (Decimal, error) {
+	result, cond := nthRoot(x, n, Context{Precision: precision})
+	return result, conditionError(cond)
+}
+
+// Computes x^n, rounded according to the given context.
+// The power is computed by squaring with a few more digits than needed (discarding the rest),
+// and the computation is repeated with more digits if that's not enough to round it correctly.
+// Note: squaring with Mult and Div would round every intermediate result to the digits of a Decimal,
+// so the accumulated error couldn't be bounded tightly enough to round the power correctly
+func powInt(x Decimal, n int64, ctx Context) (Decimal, Condition) {
+	// Negative numbers raised to an even power are positive
+	sign := x.Sign || n%2 == 0
+	// Special cases: NaN, infinities, zero and trivial powers
+	switch {
+	case x.IsNaN():
+		return NaN(), 0
+	case n == 0:
+		return Decimal{Sign: true, Value: 1}, 0
+	case x.IsInf() && n < 0:
+		return Decimal{Sign: sign}, 0
+	case x.IsInf():
+		return Inf(sign), 0
+	case x.IsZero() && n < 0:
+		// 1 / 0 = ∞
+		return Inf(sign), ConditionDivisionByZero
+	case x.IsZero():
+		return Decimal{Sign: sign}, 0
+	case n == 1:
+		return roundCoefficient(x.Sign, uint128{lo: x.Value}, false, newWidePower(x.PowerOfTen), ctx)
+	}
+	x.Compress()
+	m := uint64(n)
+	if n < 0 {
+		m = -m
+	}
+	kept := resultDigits(ctx)
+	// Each discarded digit has a relative error of at most 10^(1-digits), the ones discarded
+	// from 10^(2^j) are then multiplied m / 2^j times: in total we can be off by less than
+	// (2m + 64) * 10^(1-digits) which is (2m + 64) * 10 units in the last place (100 after the inversion)
+	margin := new(big.Int).SetUint64(m)
+	margin.Add(margin.Add(margin, margin), big.NewInt(64)).Mul(margin, big.NewInt(110))
+	for guard := uint64(10); ; guard *= 2 {
+		digits := kept[len(kept)-1] + bigDigits(margin) + guard
+		coefficient, power, inexact := powTruncated(x.Value, m, digits)
+		power = power.addWide(mulWidePower(x.PowerOfTen, m))
+		if n < 0 {
+			// 1 / (c * 10^p) = (10^shift / c) * 10^(-p-shift)
+			shift := digits + bigDigits(coefficient)
+			remainder := new(big.Int)
+			coefficient.QuoRem(bigPowerOfTen(shift), coefficient, remainder)
+			power = widePower{}.subWide(power).sub(int64(shift))
+			if !inexact && remainder.Sign() == 0 {
+				// Remove the trailing zeroes added by the division
+				ideal := widePower{}.subWide(mulWidePower(x.PowerOfTen, m))
+				for power.less(ideal) && new(big.Int).Mod(coefficient, bigTen).Sign() == 0 {
+					coefficient.Quo(coefficient, bigTen)
+					power = power.add(1)
+				}
+			}
+			inexact = inexact || remainder.Sign() != 0
+		}
+		if !inexact || guard > maxGuardDigits || !ambiguousRounding(coefficient, margin, kept) {
+			return roundBigCoefficient(sign, coefficient, inexact, power, ctx)
+		}
+	}
+}
+
+// The largest number of extra digits used to compute a result that needs to be rounded
+const maxGuardDigits = 1000
+
+// Returns value^m with at most `digits` significant digits, alongside the power of ten of the result
+// and true if any non-zero digit was discarded
+func powTruncated(value uint64, m uint64, digits uint64) (*big.Int, widePower, bool) {
+	result, resultPower := big.NewInt(1), widePower{}
+	base, basePower := new(big.Int).SetUint64(value), widePower{}
+	inexact := false
+	for {
+		if m&1 == 1 {
+			result.Mul(result, base)
+			resultPower = resultPower.addWide(basePower)
+			drop, discarded := truncateDigits(result, digits)
+			resultPower = resultPower.add(int64(drop))
+			inexact = inexact || discarded
+		}
+		m >>= 1
+		if m == 0 {
+			return result, resultPower, inexact
+		}
+		base.Mul(base, base)
+		basePower = basePower.addWide(basePower)
+		drop, discarded := truncateDigits(base, digits)
+		basePower = basePower.add(int64(drop))
+		inexact = inexact || discarded
+	}
+}
+
+// Discards the digits of v after the first `digits`, returning how many were discarded
+// and true if any of them was not zero
+func truncateDigits(v *big.Int, digits uint64) (uint64, bool) {
+	total := bigDigits(v)
+	if total <= digits {
+		return 0, false
+	}
+	remainder := new(big.Int)
+	v.QuoRem(v, bigPowerOfTen(total-digits), remainder)
+	return total - digits, remainder.Sign() != 0
+}
+
+// Returns the number of significant digits of the results rounded with the given context.
+// If it's not limited by the precision, this is 19 or 20 depending on the value of the result
+func resultDigits(ctx Context) []uint64 {
+	if 0 < ctx.Precision && ctx.Precision < len(powersOfTen) {
+		return []uint64{uint64(ctx.Precision)}
+	}
+	return []uint64{uint64(len(powersOfTen)) - 1, uint64(len(powersOfTen))}
+}
+
+// Returns true if rounding an approximation to any of the `kept` numbers of significant digits might give a
+// different result than rounding the exact value, knowing that they differ by less than margin units in the last place
+func ambiguousRounding(approximation *big.Int, margin *big.Int, kept []uint64) bool {
+	digits := bigDigits(approximation)
+	for _, k := range kept {
+		if digits <= k {
+			return true
+		}
+		// Look at the discarded digits: they must be far from zero, half and one unit of the result
+		unit := bigPowerOfTen(digits - k)
+		discarded := new(big.Int).Mod(approximation, unit)
+		for _, boundary := range []*big.Int{new(big.Int), new(big.Int).Rsh(unit, 1), unit} {
+			if new(big.Int).Sub(discarded, boundary).CmpAbs(margin) <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The largest n for which nthRoot uses Newton's method, which needs about n times more digits than the result.
+// Above it, computing e^(ln(x) / n) is cheaper since its cost doesn't grow with n
+const maxNewtonRoot = 64
+
+// Computes the n-th root of x, rounded according to the given context.
+// The root is computed exactly (with a couple more digits than needed) using Newton's method on integers,
+// or approximated with largeRoot for n > maxNewtonRoot
+func nthRoot(x Decimal, n int64, ctx Context) (Decimal, Condition) {
+	// Special cases: NaN, infinities, zero and trivial roots
+	switch {
+	case x.IsNaN():
+		return NaN(), 0
+	case n <= 0 || (!x.Sign && !x.IsZero() && n%2 == 0):
+		return NaN(), ConditionInvalidOperation
+	case x.IsInf():
+		return Inf(x.Sign), 0
+	case x.IsZero():
+		return Decimal{Sign: x.Sign}, 0
+	case n == 1:
+		return roundCoefficient(x.Sign, uint128{lo: x.Value}, false, newWidePower(x.PowerOfTen), ctx)
+	}
+	x.Compress()
+	if n > maxNewtonRoot {
+		return largeRoot(x, n, ctx)
+	}
+	// Scale the value so that its root has at least two more digits than needed
+	// and the power of ten is a multiple of n.
+	// Note: the product can't overflow a uint64 since n <= maxNewtonRoot
+	kept := resultDigits(ctx)
+	shift := uint64(0)
+	digits, needed := uint128{lo: x.Value}.digits(), uint64(n)*(kept[len(kept)-1]+2)
+	if digits < needed {
+		shift = needed - digits
+	}
+	if misaligned := (x.PowerOfTen%n - int64(shift%uint64(n))) % n; misaligned < 0 {
+		shift += uint64(misaligned + n)
+	} else {
+		shift += uint64(misaligned)
+	}
+	value := new(big.Int).Mul(new(big.Int).SetUint64(x.Value), bigPowerOfTen(shift))
+	root := bigRoot(value, uint64(n))
+	exact := new(big.Int).Exp(root, big.NewInt(n), nil).Cmp(value) == 0
+	// Note: the power of ten of the root always fits in an int64 since n >= 2
+	power := new(big.Int).Sub(big.NewInt(x.PowerOfTen), new(big.Int).SetUint64(shift))
+	power.Quo(power, big.NewInt(n))
+	if exact {
+		// Remove the trailing zeroes added by the scaling
+		ideal := new(big.Int).Div(big.NewInt(x.PowerOfTen), big.NewInt(n))
+		for power.Cmp(ideal) < 0 && new(big.Int).Mod(root, bigTen).Sign() == 0 {
+			root.Quo(root, bigTen)
+			power.Add(power, bigOne)
+		}
+	}
+	return roundBigCoefficient(x.Sign, root, !exact, newWidePower(power.Int64()), ctx)
+}
+
+// Computes the n-th root of a compressed finite non-zero x for n > maxNewtonRoot, rounded according to the given context.
+// The root is approximated as e^(ln(x) / n) with a fixed number of digits, so the cost doesn't grow with n.
+// It can only be exact if the Value of x is 1: any other root with c > 1 as its coefficient (without trailing zeroes)
+// would need c^n, which is at least 2^n, to be the Value of x times a power of ten
+func largeRoot(x Decimal, n int64, ctx Context) (Decimal, Condition) {
+	// x = V * 10^(q * n + s) with 0 <= s < n, so the root is (V * 10^s)^(1/n) * 10^q
+	q, s := x.PowerOfTen/n, x.PowerOfTen%n
+	if s < 0 {
+		q, s = q-1, s+n
+	}
+	if x.Value == 1 && s == 0 {
+		return roundCoefficient(x.Sign, uint128{lo: 1}, false, newWidePower(q), ctx)
+	}
+	coefficient, power := approximateResult(resultDigits(ctx), func(digits uint64) (*big.Int, widePower) {
+		// ln((V * 10^s)^(1/n)) = (ln(V) + s * ln(10)) / n = k * ln(10) + r with r in [0, ln(10)).
+		// Note: since s < n, the errors of the logarithms are not amplified by the division
+		scale := digits + 1
+		extra := scale + 22
+		logarithm := lnTenFixed(extra)
+		logarithm.Mul(logarithm, big.NewInt(s))
+		if x.Value != 1 {
+			// Note: ln(V) < 45, so two more significant digits are enough
+			lnValue, lnPower := lnApproximation(BigDecimal{Sign: true, Value: new(big.Int).SetUint64(x.Value)}, extra+2)
+			lnExponent, _, _ := lnPower.clamp()
+			logarithm.Add(logarithm, toFixed(BigDecimal{Sign: true, Value: lnValue, PowerOfTen: lnExponent}, extra))
+		}
+		logarithm.Quo(logarithm, big.NewInt(n))
+		k, r := new(big.Int).DivMod(logarithm, lnTenFixed(extra), new(big.Int))
+		r.Quo(r, bigPowerOfTen(extra-scale))
+		return expFixed(r, scale), newWidePower(q).add(k.Int64()).sub(int64(scale))
+	})
+	return roundBigCoefficient(x.Sign, coefficient, true, power, ctx)
+}
+
+// Returns the largest integer r such that r^n <= v, for a positive v
+func bigRoot(v *big.Int, n uint64) *big.Int {
+	// Start above the root: Newton's method then decreases monotonically towards it
+	root := new(big.Int).Lsh(bigOne, uint(uint64(v.BitLen())/n+1))
+	bigN, bigNMinusOne := new(big.Int).SetUint64(n), new(big.Int).SetUint64(n-1)
+	for {
+		// next = ((n - 1) * root + v / root^(n - 1)) / n
+		next := new(big.Int).Quo(v, new(big.Int).Exp(root, bigNMinusOne, nil))
+		next.Add(next, new(big.Int).Mul(root, bigNMinusOne)).Quo(next, bigN)
+		if next.Cmp(root) >= 0 {
+			return root
+		}
+		root = next
+	}
+}
@@ -0,0 +1,208 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestPowInt(t *testing.T) {
+	testCases := map[struct {
+		x string
+		n int64
+	}]struct {
+		result string
+		err    error
+	}{
+		{"1.05", 10}:                   {"1.6288946267774414062", nil},
+		{"1.05", -10}:                  {"0.6139132535407593744", nil},
+		{"-1.5", 3}:                    {"-3.375", nil},
+		{"-1.5", 4}:                    {"5.0625", nil},
+		{"0.99", 365}:                  {"0.02551796445229121003", nil},
+		{"1.0001", 100000}:             {"22015.45604855219865", nil},
+		{"123456789", 5}:               {"2.867971860299718107e40", nil},
+		{"3", -40}:                     {"8.225263339969959081e-20", nil},
+		{"1.000000001", -1000000000}:   {"0.3678794413553820421", nil},
+		{"2", 64}:                      {"18446744073709551620", nil},
+		{"0.5", -3}:                    {"8", nil},
+		{"-2", -1}:                     {"-0.5", nil},
+		{"10", 30}:                     {"1e30", nil},
+		{"7", 0}:                       {"1", nil},
+		{"0", 0}:                       {"1", nil},
+		{"Inf", 0}:                     {"1", nil},
+		{"NaN", 0}:                     {"NaN", nil},
+		{"-Inf", 3}:                    {"-Inf", nil},
+		{"-Inf", 2}:                    {"Inf", nil},
+		{"-Inf", -3}:                   {"-0", nil},
+		{"-0", 3}:                      {"-0", nil},
+		{"0", -1}:                      {"Inf", decimal.ErrDivisionByZero},
+		{"-0", -3}:                     {"-Inf", decimal.ErrDivisionByZero},
+		{"100", math.MaxInt64}:         {"Inf", decimal.ErrOverflow},
+		{"10", math.MaxInt64}:          {"1e9223372036854775807", nil},
+		{"0.01", math.MaxInt64}:        {"0", decimal.ErrUnderflow},
+		{"1e-9223372036854775807", 2}:  {"0", decimal.ErrUnderflow},
+		{"1e9223372036854775807", -1}:  {"1e-9223372036854775807", nil},
+		{"1e4611686018427387903", 2}:   {"1e9223372036854775806", nil},
+		{"-1e4611686018427387904", -2}: {"0.1e-9223372036854775807", nil},
+	}
+	for test, expected := range testCases {
+		result, err := decimal.PowInt(mustParse(t, test.x), test.n)
+		if !result.Equals(mustParse(t, expected.result)) || result.Sign != mustParse(t, expected.result).Sign || !errors.Is(err, expected.err) {
+			t.Fatalf("%s^%d expected (%s, %v), instead got (%v, %v)", test.x, test.n, expected.result, expected.err, result, err)
+		}
+	}
+}
+
+func TestNthRoot(t *testing.T) {
+	testCases := map[struct {
+		x         string
+		n         int64
+		precision int
+	}]struct {
+		result string
+		err    error
+	}{
+		{"2", 2, 0}:                     {"1.4142135623730950488", nil},
+		{"2", 2, 5}:                     {"1.4142", nil},
+		{"3", 2, 30}:                    {"1.7320508075688772935", nil},
+		{"0.5", 2, 0}:                   {"0.7071067811865475244", nil},
+		{"1e-5", 2, 0}:                  {"0.003162277660168379332", nil},
+		{"-8", 3, 0}:                    {"-2", nil},
+		{"100", 3, 10}:                  {"4.641588834", nil},
+		{"2", 10, 0}:                    {"1.0717734625362931642", nil},
+		{"12345678901234567890", 4, 0}:  {"59275.98020125980382", nil},
+		{"1e9223372036854775807", 2, 0}: {"3.162277660168379332e4611686018427387903", nil},
+		{"0.0004", 2, 0}:                {"0.02", nil},
+		// Large roots are approximated without Newton's method
+		{"2", 65, 0}:                             {"1.0107208637713760265", nil},
+		{"2", 100, 5}:                            {"1.0070", nil},
+		{"0.5", 1000, 0}:                         {"0.9993070929904525219", nil},
+		{"1234567890.1234567", 10000, 0}:         {"1.0020955913747456673", nil},
+		{"1234567890.1234567", 1000000, 0}:       {"1.000020934205976694", nil},
+		{"123", 1000000000000000000, 0}:          {"1.0000000000000000048", nil},
+		{"1234567890.1234567", math.MaxInt64, 0}: {"1.0000000000000000023", nil},
+		{"1e-200", 100, 0}:                       {"0.01", nil},
+		{"-1e650", 65, 0}:                        {"-1e10", nil},
+		{"7", 1, 0}:                              {"7", nil},
+		{"-0", 2, 0}:                             {"-0", nil},
+		{"Inf", 2, 0}:                            {"Inf", nil},
+		{"-Inf", 3, 0}:                           {"-Inf", nil},
+		{"NaN", 2, 0}:                            {"NaN", nil},
+		{"-4", 2, 0}:                             {"NaN", decimal.ErrIndeterminate},
+		{"-Inf", 2, 0}:                           {"NaN", decimal.ErrIndeterminate},
+		{"4", 0, 0}:                              {"NaN", decimal.ErrIndeterminate},
+		{"4", -2, 0}:                             {"NaN", decimal.ErrIndeterminate},
+	}
+	for test, expected := range testCases {
+		result, err := decimal.NthRoot(mustParse(t, test.x), test.n, test.precision)
+		if !result.Equals(mustParse(t, expected.result)) || !errors.Is(err, expected.err) {
+			t.Fatalf("%s^(1/%d) with precision %d expected (%s, %v), instead got (%v, %v)",
+				test.x, test.n, test.precision, expected.result, expected.err, result, err)
+		}
+		if test.n == 2 {
+			if sqrt, err := decimal.Sqrt(mustParse(t, test.x), test.precision); sqrt != result || !errors.Is(err, expected.err) {
+				t.Fatalf("Sqrt(%s) expected (%v, %v), instead got (%v, %v)", test.x, result, expected.err, sqrt, err)
+			}
+		}
+	}
+	// Exact roots don't have trailing zeroes
+	if result, _ := decimal.Sqrt(mustParse(t, "1.44"), 0); result != (decimal.Decimal{Sign: true, Value: 12, PowerOfTen: -1}) {
+		t.Fatalf("Expected the square root of 1.44 to be exactly 1.2, instead got %v", result)
+	}
+}
+
+func TestPowerContext(t *testing.T) {
+	inexact := decimal.ConditionInexact | decimal.ConditionRounded
+	testCases := map[struct {
+		x, op string
+		n     int64
+		ctx   decimal.Context
+	}]struct {
+		result string
+		flags  decimal.Condition
+	}{
+		{"1.05", "pow", 10, decimal.Context{Precision: 5}}:                                          {"1.6289", inexact},
+		{"1.05", "pow", 10, decimal.Context{Precision: 5, Rounding: decimal.RoundDown}}:             {"1.6288", inexact},
+		{"1.5", "pow", 2, decimal.Context{Precision: 2, Rounding: decimal.RoundHalfEven}}:           {"2.2", inexact},
+		{"1.5", "pow", 2, decimal.Context{Precision: 2, Rounding: decimal.RoundHalfUp}}:             {"2.3", inexact},
+		{"-2", "pow", -3, decimal.Context{Precision: 2, Rounding: decimal.RoundFloor}}:              {"-0.13", inexact},
+		{"-2", "pow", -3, decimal.Context{Precision: 2, Rounding: decimal.RoundCeiling}}:            {"-0.12", inexact},
+		{"10", "pow", 30, decimal.Context{ExponentLimits: true, Emax: 10}}:                          {"Inf", decimal.ConditionOverflow | inexact},
+		{"2", "pow", 4, decimal.Context{}}:                                                          {"16", 0},
+		{"2", "sqrt", 2, decimal.Context{Precision: 3, Rounding: decimal.RoundUp}}:                  {"1.42", inexact},
+		{"2", "sqrt", 2, decimal.Context{Precision: 3, Rounding: decimal.RoundDown}}:                {"1.41", inexact},
+		{"9", "sqrt", 2, decimal.Context{Precision: 3}}:                                             {"3", 0},
+		{"-9", "sqrt", 2, decimal.Context{}}:                                                        {"NaN", decimal.ConditionInvalidOperation},
+		{"-27", "root", 3, decimal.Context{Precision: 1}}:                                           {"-3", 0},
+		{"1e-7", "root", 3, decimal.Context{Precision: 3, ExponentLimits: true, Emin: -2, Emax: 3}}: {"0.0046", decimal.ConditionUnderflow | inexact},
+		{"1e-7", "root", 3, decimal.Context{Precision: 3, ExponentLimits: true, Emin: -3, Emax: 3}}: {"0.00464", inexact},
+	}
+	for test, expected := range testCases {
+		ctx := test.ctx
+		result := decimal.Decimal{}
+		var err error
+		switch test.op {
+		case "pow":
+			err = ctx.PowInt(&result, mustParse(t, test.x), test.n)
+		case "sqrt":
+			err = ctx.Sqrt(&result, mustParse(t, test.x))
+		case "root":
+			err = ctx.NthRoot(&result, mustParse(t, test.x), test.n)
+		}
+		if err != nil {
+			t.Fatalf("%s %s %d returned an error without traps: %v", test.x, test.op, test.n, err)
+		}
+		if !result.Equals(mustParse(t, expected.result)) || ctx.Flags != expected.flags {
+			t.Fatalf("%s %s %d with %+v expected (%s, %v), instead got (%v, %v)", test.x, test.op, test.n, test.ctx,
+				expected.result, expected.flags, result, ctx.Flags)
+		}
+	}
+}
+
+func BenchmarkPower(b *testing.B) {
+	x := decimal.Decimal{Sign: true, Value: 105, PowerOfTen: -2}
+	b.Run("PowInt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.PowInt(x, 360)
+		}
+	})
+	b.Run("Sqrt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			decimal.Sqrt(x, 0)
+		}
+	})
+}
+
+func FuzzPowInt(f *testing.F) {
+	f.Add(true, uint64(105), int8(-2), int8(10))
+	f.Add(false, uint64(15), int8(-1), int8(-3))
+	f.Add(true, uint64(math.MaxUint64), int8(0), int8(7))
+	f.Add(true, uint64(3), int8(5), int8(-9))
+	f.Fuzz(func(t *testing.T, sign bool, value uint64, power int8, n int8) {
+		x := decimal.Decimal{Sign: sign, Value: value, PowerOfTen: int64(power)}
+		if x.IsZero() {
+			return
+		}
+		result, err := decimal.PowInt(x, int64(n))
+		if err != nil {
+			t.Fatalf("%v^%d returned an unexpected error: %v", x, n, err)
+		}
+		// Compute the exact power
+		expected := big.NewRat(1, 1)
+		for i := 0; i < int(n) || i < -int(n); i++ {
+			expected.Mul(expected, toRat(x))
+		}
+		if n < 0 {
+			expected.Inv(expected)
+		}
+		// The result must be within half a unit of the exact power, and it must use all the digits it can
+		halfUnit := toRat(decimal.Decimal{Sign: true, Value: 5, PowerOfTen: result.PowerOfTen - 1})
+		if difference := new(big.Rat).Sub(toRat(result), expected); difference.Abs(difference).Cmp(halfUnit) > 0 ||
+			(result.Value < math.MaxUint64/10 && difference.Sign() != 0) {
+			t.Fatalf("%v^%d expected %v, instead got %v", x, n, expected.FloatString(30), result)
+		}
+	})
+}
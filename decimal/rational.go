@@ -0,0 +1,219 @@
+package decimal
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// An exact fraction: Numerator / Denominator with a sign, kept in lowest terms by the operations.
+// Unlike a Decimal, values like 1/3 don't need to be rounded.
+// A zero Denominator is treated as 1, so the zero value is zero.
+type Rational struct {
+	Sign        bool
+	Numerator   uint64
+	Denominator uint64
+}
+
+// Returns a rational based on numerator / denominator in lowest terms.
+// Returns false if the denominator is zero
+func NewRational(sign bool, numerator, denominator uint64) (r Rational, ok bool) {
+	if denominator == 0 {
+		return Rational{}, false
+	}
+	return reducedRational(sign, numerator, denominator), true
+}
+
+// Returns a rational based on a given int64
+func RationalFromInt(i int64) Rational {
+	d := DecimalFromInt(i)
+	return Rational{Sign: d.Sign, Numerator: d.Value, Denominator: 1}
+}
+
+// Returns the exact value of a decimal as a rational.
+// Returns false if the decimal is not finite, or if its numerator or denominator don't fit in an uint64
+func RationalFromDecimal(d Decimal) (r Rational, ok bool) {
+	d.Compress()
+	switch {
+	case !d.IsFinite() || d.PowerOfTen <= -int64(len(powersOfTen)):
+		return Rational{}, false
+	case d.PowerOfTen < 0:
+		return reducedRational(d.Sign, d.Value, powersOfTen[-d.PowerOfTen]), true
+	}
+	value, ok := d.integerValue()
+	if !ok {
+		return Rational{}, false
+	}
+	return reducedRational(d.Sign, value, 1), true
+}
+
+// Returns the rational as a decimal rounded with the given mode to `precision` significant digits,
+// a precision <= 0 keeps as many digits as fit in a Decimal.
+// Returns false if the decimal is not exactly equal to the rational
+//
+// Example:
+//
+//	third, _ := decimal.NewRational(true, 1, 3)
+//	third.Decimal(4, decimal.RoundHalfEven) // 0.3333, false
+func (r Rational) Decimal(precision int, mode RoundingMode) (d Decimal, exact bool) {
+	d, cond := div(DecimalFromUint(r.Sign, r.Numerator), DecimalFromUint(true, r.denominator()),
+		Context{Precision: precision, Rounding: mode})
+	return d, cond&ConditionInexact == 0
+}
+
+// Returns the rational formatted as "numerator/denominator", like "-1/3".
+// Integers are formatted without a denominator, like "7"
+func (r Rational) String() string {
+	text := strconv.FormatUint(r.Numerator, 10)
+	if !r.Sign && r.Numerator != 0 {
+		text = "-" + text
+	}
+	if denominator := r.denominator(); denominator != 1 {
+		text += "/" + strconv.FormatUint(denominator, 10)
+	}
+	return text
+}
+
+// Returns true if the rational is zero
+func (r Rational) IsZero() bool {
+	return r.Numerator == 0
+}
+
+// Returns true if the two rationals are equal
+func (r Rational) Equals(x Rational) bool {
+	return r.Cmp(x) == 0
+}
+
+// Compares two rationals, returns:
+//   - -1 if r < x
+//   - 0 if r == x
+//   - +1 if r > x
+func (r Rational) Cmp(x Rational) int {
+	switch rNegative, xNegative := !r.Sign && r.Numerator != 0, !x.Sign && x.Numerator != 0; {
+	case rNegative && !xNegative:
+		return -1
+	case !rNegative && xNegative:
+		return 1
+	case rNegative:
+		// Both negative: the one with the largest magnitude is the smallest
+		r, x = x, r
+	}
+	// Compare the cross products r.Numerator * x.Denominator and x.Numerator * r.Denominator
+	rHi, rLo := bits.Mul64(r.Numerator, x.denominator())
+	xHi, xLo := bits.Mul64(x.Numerator, r.denominator())
+	return uint128{hi: rHi, lo: rLo}.cmp(uint128{hi: xHi, lo: xLo})
+}
+
+// Perform the addition x + y and store the result in this rational.
+// If the rational is nil, this operation will be a noop.
+// If the numerator or denominator of the result don't fit in an uint64, the rational is not changed and will return false
+func (r *Rational) Add(x, y Rational) (ok bool) {
+	// Special case: nil
+	if r == nil {
+		return false // NOOP
+	}
+	result, ok := addRational(x, y)
+	if ok {
+		*r = result
+	}
+	return ok
+}
+
+// Perform the subtraction x - y and store the result in this rational.
+// If the rational is nil, this operation will be a noop.
+// If the numerator or denominator of the result don't fit in an uint64, the rational is not changed and will return false
+func (r *Rational) Sub(x, y Rational) (ok bool) {
+	y.Sign = !y.Sign
+	return r.Add(x, y)
+}
+
+// Perform the multiplication x * y and store the result in this rational.
+// If the rational is nil, this operation will be a noop.
+// If the numerator or denominator of the result don't fit in an uint64, the rational is not changed and will return false
+func (r *Rational) Mult(x, y Rational) (ok bool) {
+	// Special case: nil
+	if r == nil {
+		return false // NOOP
+	}
+	result, ok := multRational(x, y)
+	if ok {
+		*r = result
+	}
+	return ok
+}
+
+// Perform the division x / y and store the result in this rational.
+// If the rational is nil, this operation will be a noop.
+// If the numerator or denominator of the result don't fit in an uint64, the rational is not changed and will return false
+// If a divide-by-zero error is encountered, the rational is not changed and will return false
+func (r *Rational) Div(x, y Rational) (ok bool) {
+	// Special case: divide by zero
+	if y.Numerator == 0 {
+		return false
+	}
+	// x / y = x * (1 / y)
+	y.Numerator, y.Denominator = y.denominator(), y.Numerator
+	return r.Mult(x, y)
+}
+
+// Performs the addition x + y in lowest terms, using the least common multiple of the denominators
+func addRational(x, y Rational) (Rational, bool) {
+	x, y = reducedRational(x.Sign, x.Numerator, x.denominator()), reducedRational(y.Sign, y.Numerator, y.denominator())
+	xDenominator, yDenominator := x.denominator(), y.denominator()
+	denominator, overflow := lcm(xDenominator, yDenominator)
+	if overflow {
+		return Rational{}, false
+	}
+	// Note: the numerators are scaled to the common denominator with 128 bits, they might fit in 64 once reduced
+	xNumerator := uint128{lo: x.Numerator}.mul64(denominator / xDenominator)
+	yNumerator := uint128{lo: y.Numerator}.mul64(denominator / yDenominator)
+	sign, numerator := x.Sign, uint128{}
+	switch {
+	case x.Sign == y.Sign:
+		numerator = xNumerator.add(yNumerator)
+		if numerator.cmp(xNumerator) < 0 {
+			return Rational{}, false
+		}
+	case xNumerator.cmp(yNumerator) >= 0:
+		numerator = xNumerator.sub(yNumerator)
+	default:
+		sign, numerator = y.Sign, yNumerator.sub(xNumerator)
+	}
+	// Reduce the fraction: gcd(numerator, denominator) = gcd(denominator, numerator % denominator)
+	_, remainder := numerator.divmod64(denominator)
+	divisor := gcd(denominator, remainder)
+	numerator, _ = numerator.divmod64(divisor)
+	if numerator.hi != 0 {
+		return Rational{}, false
+	}
+	return reducedRational(sign, numerator.lo, denominator/divisor), true
+}
+
+// Performs the multiplication x * y in lowest terms, simplifying each numerator with the other denominator first
+func multRational(x, y Rational) (Rational, bool) {
+	x, y = reducedRational(x.Sign, x.Numerator, x.denominator()), reducedRational(y.Sign, y.Numerator, y.denominator())
+	xDenominator, yDenominator := x.denominator(), y.denominator()
+	xDivisor, yDivisor := gcd(x.Numerator, yDenominator), gcd(y.Numerator, xDenominator)
+	numeratorHi, numerator := bits.Mul64(x.Numerator/xDivisor, y.Numerator/yDivisor)
+	denominatorHi, denominator := bits.Mul64(xDenominator/yDivisor, yDenominator/xDivisor)
+	if numeratorHi != 0 || denominatorHi != 0 {
+		return Rational{}, false
+	}
+	return reducedRational(x.Sign == y.Sign, numerator, denominator), true
+}
+
+// Returns sign * numerator / denominator in lowest terms, zero is always positive
+func reducedRational(sign bool, numerator, denominator uint64) Rational {
+	if numerator == 0 {
+		return Rational{Sign: true, Denominator: 1}
+	}
+	divisor := gcd(numerator, denominator)
+	return Rational{Sign: sign, Numerator: numerator / divisor, Denominator: denominator / divisor}
+}
+
+// Returns the denominator of the rational, treating zero as 1
+func (r Rational) denominator() uint64 {
+	if r.Denominator == 0 {
+		return 1
+	}
+	return r.Denominator
+}
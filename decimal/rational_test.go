@@ -0,0 +1,235 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestRational(t *testing.T) {
+	testCases := map[struct {
+		x, y decimal.Rational
+	}]struct {
+		add, sub, mult, div string
+	}{
+		{decimal.Rational{true, 1, 3}, decimal.Rational{true, 1, 6}}:  {"1/2", "1/6", "1/18", "2"},
+		{decimal.Rational{true, 1, 3}, decimal.Rational{false, 1, 3}}: {"0", "2/3", "-1/9", "-1"},
+		{decimal.Rational{false, 3, 4}, decimal.Rational{true, 1, 4}}: {"-1/2", "-1", "-3/16", "-3"},
+		{decimal.Rational{true, 0, 0}, decimal.Rational{true, 5, 7}}:  {"5/7", "-5/7", "0", "0"},
+		{decimal.Rational{true, 7, 0}, decimal.Rational{true, 2, 1}}:  {"9", "5", "14", "7/2"},
+		{decimal.Rational{true, 2, 9}, decimal.Rational{true, 0, 1}}:  {"2/9", "2/9", "0", ""},
+		{decimal.Rational{true, 1, 1 << 40}, decimal.Rational{true, 1, 3 << 30}}: {
+			"1027/3298534883328", "-1021/3298534883328", "", "3/1024",
+		},
+		{decimal.Rational{true, math.MaxUint64, 2}, decimal.Rational{true, math.MaxUint64, 2}}: {
+			"18446744073709551615", "0", "", "1",
+		},
+		{decimal.Rational{true, math.MaxUint64, 7}, decimal.Rational{true, math.MaxUint64, 11}}: {
+			"", "", "", "11/7",
+		},
+		{decimal.Rational{true, math.MaxUint64 - 1, 3}, decimal.Rational{true, math.MaxUint64, 3}}: {
+			"", "-1/3", "", "18446744073709551614/18446744073709551615",
+		},
+	}
+	for test, expected := range testCases {
+		operations := map[string]struct {
+			op       func(r *decimal.Rational, x, y decimal.Rational) bool
+			expected string
+		}{
+			"Add":  {(*decimal.Rational).Add, expected.add},
+			"Sub":  {(*decimal.Rational).Sub, expected.sub},
+			"Mult": {(*decimal.Rational).Mult, expected.mult},
+			"Div":  {(*decimal.Rational).Div, expected.div},
+		}
+		for name, operation := range operations {
+			r := decimal.Rational{true, 42, 1}
+			ok := operation.op(&r, test.x, test.y)
+			if operation.expected == "" {
+				// The operation fails and the rational is not changed
+				if ok || r != (decimal.Rational{true, 42, 1}) {
+					t.Fatalf("%s(%v, %v) expected to fail, instead got (%v, %v)", name, test.x, test.y, r, ok)
+				}
+			} else if !ok || r.String() != operation.expected {
+				t.Fatalf("%s(%v, %v) expected %s, instead got (%v, %v)", name, test.x, test.y, operation.expected, r, ok)
+			}
+		}
+	}
+	// Nil rationals are a noop
+	var r *decimal.Rational
+	if r.Add(decimal.RationalFromInt(1), decimal.RationalFromInt(2)) || r.Div(decimal.RationalFromInt(1), decimal.RationalFromInt(2)) {
+		t.Fatalf("Expected operations on a nil rational to fail")
+	}
+}
+
+func TestRationalConversions(t *testing.T) {
+	if r, ok := decimal.NewRational(false, 6, 4); !ok || r != (decimal.Rational{false, 3, 2}) {
+		t.Fatalf("NewRational(false, 6, 4) expected (-3/2, true), instead got (%v, %v)", r, ok)
+	}
+	if r, ok := decimal.NewRational(true, 1, 0); ok {
+		t.Fatalf("NewRational(true, 1, 0) expected to fail, instead got %v", r)
+	}
+	if r, ok := decimal.NewRational(false, 0, 5); !ok || r != (decimal.Rational{true, 0, 1}) {
+		t.Fatalf("NewRational(false, 0, 5) expected (0, true), instead got (%v, %v)", r, ok)
+	}
+	if r := decimal.RationalFromInt(math.MinInt64); r != (decimal.Rational{false, 1 << 63, 1}) {
+		t.Fatalf("RationalFromInt(MinInt64) expected -9223372036854775808, instead got %v", r)
+	}
+	fromDecimal := map[string]string{
+		"0.75":                     "3/4",
+		"-12.5":                    "-25/2",
+		"1200":                     "1200",
+		"0e-30":                    "0",
+		"18446744073709551615e-19": "3689348814741910323/2000000000000000000",
+		"1e-19":                    "1/10000000000000000000",
+		"1e-20":                    "",
+		"1e20":                     "",
+		"12300000000000000000e-20": "123/1000",
+		"NaN":                      "",
+		"Inf":                      "",
+	}
+	for test, expected := range fromDecimal {
+		r, ok := decimal.RationalFromDecimal(mustParse(t, test))
+		if ok != (expected != "") || (ok && r.String() != expected) {
+			t.Fatalf("RationalFromDecimal(%s) expected %s, instead got (%v, %v)", test, expected, r, ok)
+		}
+	}
+	toDecimal := map[struct {
+		r         decimal.Rational
+		precision int
+		mode      decimal.RoundingMode
+	}]struct {
+		result string
+		exact  bool
+	}{
+		{decimal.Rational{true, 1, 3}, 4, decimal.RoundHalfEven}:  {"0.3333", false},
+		{decimal.Rational{true, 2, 3}, 4, decimal.RoundHalfEven}:  {"0.6667", false},
+		{decimal.Rational{true, 2, 3}, 4, decimal.RoundDown}:      {"0.6666", false},
+		{decimal.Rational{false, 1, 3}, 2, decimal.RoundFloor}:    {"-0.34", false},
+		{decimal.Rational{true, 1, 3}, 0, decimal.RoundHalfEven}:  {"0.3333333333333333333", false},
+		{decimal.Rational{true, 3, 8}, 0, decimal.RoundHalfEven}:  {"0.375", true},
+		{decimal.Rational{true, 5, 2}, 1, decimal.RoundHalfEven}:  {"2", false},
+		{decimal.Rational{true, 7, 0}, 0, decimal.RoundHalfEven}:  {"7", true},
+		{decimal.Rational{false, 0, 1}, 3, decimal.RoundHalfEven}: {"0", true},
+	}
+	for test, expected := range toDecimal {
+		result, exact := test.r.Decimal(test.precision, test.mode)
+		if !result.Equals(mustParse(t, expected.result)) || exact != expected.exact {
+			t.Fatalf("%v.Decimal(%d, %d) expected (%s, %v), instead got (%v, %v)",
+				test.r, test.precision, test.mode, expected.result, expected.exact, result, exact)
+		}
+	}
+}
+
+func TestRationalCmp(t *testing.T) {
+	testCases := map[[2]decimal.Rational]int{
+		{{true, 1, 3}, {true, 1, 3}}:                           0,
+		{{true, 1, 3}, {true, 2, 6}}:                           0,
+		{{true, 1, 3}, {true, 1, 2}}:                           -1,
+		{{false, 1, 3}, {false, 1, 2}}:                         1,
+		{{false, 1, 3}, {true, 0, 1}}:                          -1,
+		{{false, 0, 1}, {true, 0, 0}}:                          0,
+		{{true, math.MaxUint64, 3}, {true, math.MaxUint64, 2}}: -1,
+		{{true, 5, 0}, {true, 5, 1}}:                           0,
+	}
+	for test, expected := range testCases {
+		if cmp := test[0].Cmp(test[1]); cmp != expected {
+			t.Fatalf("%v.Cmp(%v) expected %d, instead got %d", test[0], test[1], expected, cmp)
+		}
+		if equals := test[0].Equals(test[1]); equals != (expected == 0) {
+			t.Fatalf("%v.Equals(%v) expected %v, instead got %v", test[0], test[1], expected == 0, equals)
+		}
+		if cmp := test[1].Cmp(test[0]); cmp != -expected {
+			t.Fatalf("%v.Cmp(%v) expected %d, instead got %d", test[1], test[0], -expected, cmp)
+		}
+	}
+}
+
+func BenchmarkRational(b *testing.B) {
+	x, y := decimal.Rational{true, 1, 3}, decimal.Rational{false, 5, 12}
+	r := decimal.Rational{}
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r.Add(x, y)
+		}
+	})
+	b.Run("Mult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r.Mult(x, y)
+		}
+	})
+	b.Run("Decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Decimal(10, decimal.RoundHalfEven)
+		}
+	})
+}
+
+func FuzzRational(f *testing.F) {
+	// Converts a rational to a big.Rat
+	rationalToRat := func(r decimal.Rational) *big.Rat {
+		denominator := new(big.Int).SetUint64(r.Denominator)
+		if r.Denominator == 0 {
+			denominator.SetUint64(1)
+		}
+		rat := new(big.Rat).SetFrac(new(big.Int).SetUint64(r.Numerator), denominator)
+		if !r.Sign {
+			rat.Neg(rat)
+		}
+		return rat
+	}
+	f.Add(true, uint64(1), uint64(3), false, uint64(5), uint64(12))
+	f.Add(true, uint64(math.MaxUint64), uint64(2), true, uint64(math.MaxUint64), uint64(2))
+	f.Add(false, uint64(0), uint64(0), true, uint64(1<<40), uint64(3<<30))
+	f.Fuzz(func(t *testing.T, xSign bool, xNumerator, xDenominator uint64, ySign bool, yNumerator, yDenominator uint64) {
+		x := decimal.Rational{Sign: xSign, Numerator: xNumerator, Denominator: xDenominator}
+		y := decimal.Rational{Sign: ySign, Numerator: yNumerator, Denominator: yDenominator}
+		bigX, bigY := rationalToRat(x), rationalToRat(y)
+		if cmp := x.Cmp(y); cmp != bigX.Cmp(bigY) {
+			t.Fatalf("%v.Cmp(%v) expected %d, instead got %d", x, y, bigX.Cmp(bigY), cmp)
+		}
+		operations := map[string]struct {
+			op       func(r *decimal.Rational, x, y decimal.Rational) bool
+			expected *big.Rat
+		}{
+			"Add":  {(*decimal.Rational).Add, new(big.Rat).Add(bigX, bigY)},
+			"Sub":  {(*decimal.Rational).Sub, new(big.Rat).Sub(bigX, bigY)},
+			"Mult": {(*decimal.Rational).Mult, new(big.Rat).Mul(bigX, bigY)},
+		}
+		if bigY.Sign() != 0 {
+			operations["Div"] = struct {
+				op       func(r *decimal.Rational, x, y decimal.Rational) bool
+				expected *big.Rat
+			}{(*decimal.Rational).Div, new(big.Rat).Quo(bigX, bigY)}
+		}
+		for name, operation := range operations {
+			r := decimal.Rational{}
+			// The operation only fails if the result in lowest terms doesn't fit
+			fits := new(big.Int).Abs(operation.expected.Num()).IsUint64() && operation.expected.Denom().IsUint64()
+			ok := operation.op(&r, x, y)
+			if ok != fits || (ok && (rationalToRat(r).Cmp(operation.expected) != 0 || r.Denominator == 0)) {
+				t.Fatalf("%s(%v, %v) expected %v, instead got (%v, %v)", name, x, y, operation.expected, r, ok)
+			}
+			divisor := new(big.Int).GCD(nil, nil, new(big.Int).SetUint64(r.Numerator), new(big.Int).SetUint64(r.Denominator))
+			if ok && r.Numerator != 0 && divisor.Cmp(big.NewInt(1)) != 0 {
+				t.Fatalf("%s(%v, %v) returned %v, which is not in lowest terms", name, x, y, r)
+			}
+		}
+		// Conversion to decimal is correctly rounded
+		result, exact := x.Decimal(0, decimal.RoundHalfEven)
+		if back, ok := decimal.RationalFromDecimal(result); exact && (!ok || !back.Equals(x)) {
+			t.Fatalf("%v.Decimal(0) returned %v, which is not exact", x, result)
+		}
+		if r := result.Rat(); r != nil {
+			difference := new(big.Rat).Sub(r, bigX)
+			halfUnit := new(big.Rat).SetFrac(big.NewInt(5), new(big.Int).Exp(big.NewInt(10), big.NewInt(1-result.PowerOfTen), nil))
+			if result.PowerOfTen > 0 {
+				halfUnit.SetInt(new(big.Int).Mul(big.NewInt(5), new(big.Int).Exp(big.NewInt(10), big.NewInt(result.PowerOfTen-1), nil)))
+			}
+			if difference.Abs(difference).Cmp(halfUnit) > 0 || exact != (difference.Sign() == 0) {
+				t.Fatalf("%v.Decimal(0) returned (%v, %v)", x, result, exact)
+			}
+		}
+	})
+}
@@ -0,0 +1,264 @@
+package decimal
+
+import (
+	"math"
+)
+
+// Describes how a number is rounded when some of its digits have to be discarded
+type RoundingMode uint8
+
+const (
+	// Round to the nearest neighbour, ties go to the even neighbour (banker's rounding)
+	RoundHalfEven RoundingMode = iota
+	// Round to the nearest neighbour, ties go away from zero
+	RoundHalfUp
+	// Round to the nearest neighbour, ties go towards zero
+	RoundHalfDown
+	// Round away from zero
+	RoundUp
+	// Round towards zero (truncate)
+	RoundDown
+	// Round towards positive infinity
+	RoundCeiling
+	// Round towards negative infinity
+	RoundFloor
+)
+
+// Returns true if a truncated (rounded towards zero) value should be incremented by one unit, given:
+//   - sign, the sign of the value
+//   - odd, if the truncated value is odd
+//   - half, the discarded part compared to half a unit: -1 if less, 0 if equal, 1 if greater
+//   - inexact, if the discarded part is not zero
+func (mode RoundingMode) increment(sign bool, odd bool, half int, inexact bool) bool {
+	if !inexact {
+		return false
+	}
+	switch mode {
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && odd)
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundUp:
+		return true
+	case RoundCeiling:
+		return sign
+	case RoundFloor:
+		return !sign
+	}
+	return false // RoundDown
+}
+
+// Discards the last `digits` digits of value rounding the result with the given mode.
+// If sticky is true, the discarded part is treated as slightly larger than its digits.
+// Returns the rounded value and true if any non-zero part was discarded.
+func roundDigits(value uint64, digits uint64, sticky bool, sign bool, mode RoundingMode) (uint64, bool) {
+	if digits == 0 {
+		return value, false
+	}
+	quotient, remainder := uint64(0), value
+	half := -1 // With 20+ digits discarded, the value is always below half
+	if digits < uint64(len(powersOfTen)) {
+		power := powersOfTen[digits]
+		quotient, remainder = value/power, value%power
+		switch {
+		case remainder > power/2 || (remainder == power/2 && sticky):
+			half = 1
+		case remainder == power/2:
+			half = 0
+		}
+	}
+	inexact := remainder != 0 || sticky
+	if mode.increment(sign, quotient%2 == 1, half, inexact) {
+		quotient++
+	}
+	return quotient, inexact
+}
+
+// Rounds the number to at most `scale` digits after the decimal point using the given rounding mode.
+// A negative scale rounds to tens, hundreds, and so on.
+// Returns true if the result is inexact (a non-zero part was discarded).
+//
+// Examples:
+//   - {true, 125, -2}.Round(1, RoundHalfEven): {true, 12, -1}, true
+//   - {false, 125, -2}.Round(1, RoundFloor): {false, 13, -1}, true
+//   - {true, 1250, 0}.Round(-2, RoundHalfUp): {true, 13, 2}, true
+func (d *Decimal) Round(scale int64, mode RoundingMode) (inexact bool) {
+	if d == nil || !d.IsFinite() {
+		return false
+	}
+	// The resulting power of ten needs to be representable
+	if scale == math.MinInt64 {
+		scale++
+	}
+	if d.PowerOfTen >= -scale {
+		return false // Nothing to round
+	}
+	// Note: the subtraction can't overflow a uint64 since -scale > d.PowerOfTen
+	digits := uint64(-scale) - uint64(d.PowerOfTen)
+	d.Value, inexact = roundDigits(d.Value, digits, false, d.Sign, mode)
+	d.PowerOfTen = -scale
+	*d = asFinite(*d)
+	return inexact
+}
+
+// Changes the power of ten of this number to match the one of exp, rounding with the given mode if needed.
+// Returns true in inexact if the result is inexact (a non-zero part was discarded).
+// If the value can't be represented with the new power of ten, the number is left untouched and ok is false.
+// A NaN stays NaN, and infinities can only be quantized to infinity.
+//
+// Examples:
+//   - {true, 12345, -3}.Quantize({true, 1, -2}, RoundHalfEven): {true, 1234, -2}, true, true
+//   - {true, 5, 0}.Quantize({true, 100, -2}, RoundHalfEven): {true, 500, -2}, false, true
+func (d *Decimal) Quantize(exp Decimal, mode RoundingMode) (inexact bool, ok bool) {
+	if d == nil {
+		return false, false
+	}
+	// Special case: NaN and infinities
+	if !d.IsFinite() || !exp.IsFinite() {
+		switch {
+		case d.IsNaN() || exp.IsNaN():
+			*d = NaN()
+			return false, true
+		case d.IsInf() && exp.IsInf():
+			return false, true
+		}
+		return false, false
+	}
+	if d.PowerOfTen < exp.PowerOfTen {
+		return d.Round(-exp.PowerOfTen, mode), true
+	}
+	if d.Value != 0 {
+		// Note: the subtraction can't overflow a uint64 since d.PowerOfTen >= exp.PowerOfTen
+		digits := uint64(d.PowerOfTen) - uint64(exp.PowerOfTen)
+		if digits >= uint64(len(powersOfTen)) || overflow_multiplication(d.Value, powersOfTen[digits]) {
+			return false, false
+		}
+		d.Value *= powersOfTen[digits]
+	}
+	d.PowerOfTen = exp.PowerOfTen
+	*d = asFinite(*d)
+	return false, true
+}
+
+// Rounds sign * coefficient * 10^power to a Decimal with at most ctx.Precision significant digits
+// (as many as fit in a uint64 if ctx.Precision <= 0) and a power of ten within the context limits,
+// using the context rounding mode. The context flags and traps are ignored.
+// If sticky is true the coefficient is treated as slightly larger than its value, this is
+// only allowed if the coefficient has more digits than the ones that can be kept.
+func roundCoefficient(sign bool, coefficient uint128, sticky bool, power widePower, ctx Context) (Decimal, Condition) {
+	// Special case: zero
+	if coefficient.isZero() && !sticky {
+		return Decimal{Sign: sign}, 0
+	}
+	precision := ctx.Precision
+	// Fast path: nothing to round
+	if coefficient.hi == 0 && !sticky && !ctx.ExponentLimits && (precision <= 0 || precision >= len(powersOfTen) || coefficient.lo < powersOfTen[precision]) {
+		if power, under, over := power.clamp(); under == 0 && !over {
+			return Decimal{Sign: sign, Value: coefficient.lo, PowerOfTen: power}, 0
+		}
+	}
+	// Find the largest coefficient allowed and how many digits need to be discarded to get to it
+	limit, limitDigits := uint128{lo: math.MaxUint64}, uint64(len(powersOfTen))
+	if 0 < precision && precision < len(powersOfTen) {
+		limit.lo, limitDigits = powersOfTen[precision]-1, uint64(precision)
+	}
+	drop := uint64(0)
+	if digits := coefficient.digits(); digits > limitDigits {
+		drop = digits - limitDigits
+	}
+	// The power of ten can't go below emin so we might need to discard even more digits, in which case
+	// the result is subnormal. With exponent limits emin is Emin - (limitDigits - 1), so this happens
+	// when the adjusted exponent is below Emin
+	emin := int64(math.MinInt64)
+	if ctx.ExponentLimits {
+		emin, _, _ = newWidePower(ctx.Emin).sub(int64(limitDigits) - 1).clamp()
+	}
+	_, under, _ := power.clampTo(emin, math.MaxInt64)
+	subnormal := under > drop
+	if subnormal {
+		drop = under
+	}
+	for {
+		quotient, last, rest := coefficient.shiftDigits(drop)
+		rest = rest || sticky
+		half := -1
+		switch {
+		case last > 5 || (last == 5 && rest):
+			half = 1
+		case last == 5:
+			half = 0
+		}
+		inexact := last != 0 || rest
+		if ctx.Rounding.increment(sign, quotient.lo%2 == 1, half, inexact) {
+			quotient = quotient.add64(1)
+		}
+		if quotient.cmp(limit) > 0 {
+			// Too many digits left, try again discarding one more
+			drop++
+			continue
+		}
+		// Prepare the result
+		result := Decimal{Sign: sign, Value: quotient.lo}
+		var cond Condition
+		if drop > 0 {
+			cond |= ConditionRounded
+		}
+		if inexact {
+			cond |= ConditionInexact
+			if subnormal {
+				cond |= ConditionUnderflow
+			}
+		}
+		if result.Value == 0 {
+			return result, cond
+		}
+		if under > 0 {
+			// Note: drop >= under so this can't overflow
+			power = newWidePower(emin).add(int64(drop - under))
+		} else {
+			if drop > math.MaxInt64 {
+				drop = math.MaxInt64 // Will overflow anyway
+			}
+			power = power.add(int64(drop))
+		}
+		// With exponent limits, the largest power of ten depends on the number of digits of the result
+		emax := newWidePower(math.MaxInt64)
+		if ctx.ExponentLimits {
+			emax = newWidePower(ctx.Emax).sub(int64(quotient.digits()) - 1)
+		}
+		if emax.less(power) {
+			// Try to bring the power of ten down to math.MaxInt64 by adding zeroes to the value
+			if excess := power.sub(math.MaxInt64); !ctx.ExponentLimits && excess.hi == 0 && excess.lo < uint64(len(powersOfTen)) &&
+				quotient.mul64(powersOfTen[excess.lo]).cmp(limit) <= 0 {
+				result.Value *= powersOfTen[excess.lo]
+				result.PowerOfTen = math.MaxInt64
+				return result, cond
+			}
+			cond |= ConditionInexact | ConditionRounded | ConditionOverflow
+			// Round to infinity unless the rounding mode would not round a value above half a unit up,
+			// in which case we keep the largest representable number
+			if ctx.Rounding.increment(sign, false, 1, true) {
+				return Inf(sign), cond
+			}
+			if !ctx.ExponentLimits {
+				return Decimal{Sign: sign, Value: limit.lo, PowerOfTen: math.MaxInt64}, cond
+			}
+			return largestFinite(sign, limit.lo, limitDigits, ctx.Emax), cond
+		}
+		result.PowerOfTen = int64(power.lo)
+		return result, cond
+	}
+}
+
+// Returns the largest number with a coefficient up to limit (which has limitDigits digits)
+// and an adjusted exponent up to emax
+func largestFinite(sign bool, limit uint64, limitDigits uint64, emax int64) Decimal {
+	power, under, _ := newWidePower(emax).sub(int64(limitDigits) - 1).clamp()
+	if under > 0 {
+		// Keep only the digits that fit above math.MinInt64
+		limit = powersOfTen[limitDigits-under] - 1
+	}
+	return Decimal{Sign: sign, Value: limit, PowerOfTen: power}
+}
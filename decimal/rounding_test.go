@@ -0,0 +1,138 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+var roundingModes = []decimal.RoundingMode{
+	decimal.RoundHalfEven,
+	decimal.RoundHalfUp,
+	decimal.RoundHalfDown,
+	decimal.RoundUp,
+	decimal.RoundDown,
+	decimal.RoundCeiling,
+	decimal.RoundFloor,
+}
+
+func TestRound(t *testing.T) {
+	// Results are in the order of roundingModes:
+	// HalfEven, HalfUp, HalfDown, Up, Down, Ceiling, Floor
+	testCases := map[struct {
+		number string
+		scale  int64
+	}][7]string{
+		{"5.5", 0}:                    {"6", "6", "5", "6", "5", "6", "5"},
+		{"2.5", 0}:                    {"2", "3", "2", "3", "2", "3", "2"},
+		{"1.6", 0}:                    {"2", "2", "2", "2", "1", "2", "1"},
+		{"1.1", 0}:                    {"1", "1", "1", "2", "1", "2", "1"},
+		{"1.0", 0}:                    {"1", "1", "1", "1", "1", "1", "1"},
+		{"-1.0", 0}:                   {"-1", "-1", "-1", "-1", "-1", "-1", "-1"},
+		{"-1.1", 0}:                   {"-1", "-1", "-1", "-2", "-1", "-1", "-2"},
+		{"-1.6", 0}:                   {"-2", "-2", "-2", "-2", "-1", "-1", "-2"},
+		{"-2.5", 0}:                   {"-2", "-3", "-2", "-3", "-2", "-2", "-3"},
+		{"-5.5", 0}:                   {"-6", "-6", "-5", "-6", "-5", "-5", "-6"},
+		{"0.4", 0}:                    {"0", "0", "0", "1", "0", "1", "0"},
+		{"-0.4", 0}:                   {"0", "0", "0", "-1", "0", "0", "-1"},
+		{"2.5000001", 0}:              {"3", "3", "3", "3", "2", "3", "2"},
+		{"1.005", 2}:                  {"1", "1.01", "1", "1.01", "1", "1.01", "1"},
+		{"-1.015", 2}:                 {"-1.02", "-1.02", "-1.01", "-1.02", "-1.01", "-1.01", "-1.02"},
+		{"0.125", 2}:                  {"0.12", "0.13", "0.12", "0.13", "0.12", "0.13", "0.12"},
+		{"1250", -2}:                  {"1200", "1300", "1200", "1300", "1200", "1300", "1200"},
+		{"-1350", -2}:                 {"-1400", "-1400", "-1300", "-1400", "-1300", "-1300", "-1400"},
+		{"123.456", 5}:                {"123.456", "123.456", "123.456", "123.456", "123.456", "123.456", "123.456"},
+		{"9.99", 1}:                   {"10", "10", "10", "10", "9.9", "10", "9.9"},
+		{"5e-30", 0}:                  {"0", "0", "0", "1", "0", "1", "0"},
+		{"-5e-30", 0}:                 {"0", "0", "0", "-1", "0", "0", "-1"},
+		{"5e-20", 19}:                 {"0", "1e-19", "0", "1e-19", "0", "1e-19", "0"},
+		{"15e-21", 19}:                {"0", "0", "0", "1e-19", "0", "1e-19", "0"},
+		{"18446744073709551615", -19}: {"2e19", "2e19", "2e19", "2e19", "1e19", "2e19", "1e19"},
+	}
+	for test, results := range testCases {
+		number, err := decimal.ParseString(test.number)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		for i, mode := range roundingModes {
+			expected, err := decimal.ParseString(results[i])
+			if err != nil {
+				t.Fatalf("Failed to setup test: %v", err)
+			}
+			actual := number.Clone()
+			inexact := actual.Round(test.scale, mode)
+			if !actual.Equals(expected) {
+				t.Fatalf("%q rounded to %d digits with mode %d expected %v, instead got %v",
+					test.number, test.scale, mode, expected, actual)
+			}
+			if actual.PowerOfTen < -test.scale {
+				t.Fatalf("%q rounded to %d digits with mode %d has too many digits: %v",
+					test.number, test.scale, mode, actual)
+			}
+			if expectedInexact := !number.Equals(expected); inexact != expectedInexact {
+				t.Fatalf("%q rounded to %d digits with mode %d reported inexact %v, but expected %v",
+					test.number, test.scale, mode, inexact, expectedInexact)
+			}
+		}
+	}
+	// Can Round() handle nil without panic?
+	var nilDecimal *decimal.Decimal = nil
+	if nilDecimal.Round(0, decimal.RoundHalfEven) {
+		t.Fatal("Expected Round() on nil to be exact")
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	testCases := map[struct {
+		number, exp string
+		mode        decimal.RoundingMode
+	}]struct {
+		result      decimal.Decimal
+		inexact, ok bool
+	}{
+		{"12.345", "0.01", decimal.RoundHalfEven}: {decimal.Decimal{Sign: true, Value: 1234, PowerOfTen: -2}, true, true},
+		{"12.345", "0.01", decimal.RoundHalfUp}:   {decimal.Decimal{Sign: true, Value: 1235, PowerOfTen: -2}, true, true},
+		{"-12.345", "0.01", decimal.RoundCeiling}: {decimal.Decimal{Sign: false, Value: 1234, PowerOfTen: -2}, true, true},
+		{"5", "1.00", decimal.RoundHalfEven}:      {decimal.Decimal{Sign: true, Value: 500, PowerOfTen: -2}, false, true},
+		{"0", "1.000", decimal.RoundHalfEven}:     {decimal.Decimal{Sign: true, Value: 0, PowerOfTen: -3}, false, true},
+		{"1.5", "1e1", decimal.RoundHalfEven}:     {decimal.Decimal{Sign: true, Value: 0, PowerOfTen: 1}, true, true},
+		{"1.5", "1.5", decimal.RoundHalfEven}:     {decimal.Decimal{Sign: true, Value: 15, PowerOfTen: -1}, false, true},
+		{"123", "1e-18", decimal.RoundHalfEven}:   {decimal.Decimal{Sign: true, Value: 123, PowerOfTen: 0}, false, false},
+		{"1", "1e-19", decimal.RoundHalfEven}:     {decimal.Decimal{Sign: true, Value: 10000000000000000000, PowerOfTen: -19}, false, true},
+		{"1", "1e-20", decimal.RoundHalfEven}:     {decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 0}, false, false},
+		{"NaN", "0.01", decimal.RoundHalfEven}:    {decimal.NaN(), false, true},
+		{"12.345", "NaN", decimal.RoundHalfEven}:  {decimal.NaN(), false, true},
+		{"-Inf", "Inf", decimal.RoundHalfEven}:    {decimal.Inf(false), false, true},
+		{"Inf", "0.01", decimal.RoundHalfEven}:    {decimal.Inf(true), false, false},
+		{"12.345", "Inf", decimal.RoundHalfEven}:  {decimal.Decimal{Sign: true, Value: 12345, PowerOfTen: -3}, false, false},
+	}
+	for test, expected := range testCases {
+		number, err := decimal.ParseString(test.number)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		exp, err := decimal.ParseString(test.exp)
+		if err != nil {
+			t.Fatalf("Failed to setup test: %v", err)
+		}
+		inexact, ok := number.Quantize(exp, test.mode)
+		if number != expected.result || inexact != expected.inexact || ok != expected.ok {
+			t.Fatalf("%q quantized to %q with mode %d expected (%v, %v, %v), instead got (%v, %v, %v)",
+				test.number, test.exp, test.mode,
+				expected.result, expected.inexact, expected.ok,
+				number, inexact, ok)
+		}
+	}
+	// Can Quantize() handle nil without panic?
+	var nilDecimal *decimal.Decimal = nil
+	if _, ok := nilDecimal.Quantize(decimal.Decimal{}, decimal.RoundHalfEven); ok {
+		t.Fatal("Expected Quantize() on nil to fail")
+	}
+}
+
+func BenchmarkRound(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		d := decimal.Decimal{Sign: true, Value: 123456789, PowerOfTen: -6}
+		d.Round(2, decimal.RoundHalfEven)
+	}
+}
@@ -0,0 +1,144 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrorParsingSyntax    = errors.New("the given string is not a valid number")
+	ErrorParsingPrecision = errors.New("the given string has more significant digits than a Decimal can store")
+)
+
+// The error returned by ParseStrict and ParseLocale, with the position in the input where parsing failed.
+// Err is one of ErrorParsingSyntax, ErrorParsingPrecision, ErrorParsingOverflow or ErrorParsingGrouping,
+// so it can be checked with errors.Is
+type ParseError struct {
+	Input  string // The string that was parsed
+	Offset int    // The byte offset in Input where the error was found
+	Reason string // A description of the error, like "unexpected character 'a'"
+	Err    error
+}
+
+// Returns a description of the error with its position
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse %q: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+// Returns the error describing the kind of failure
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse a decimal number from a given string, returning a *ParseError if it's not a well-formed number.
+// Accepts an optional sign, digits with at most one '.', an optional exponent ('e' or 'E' followed by
+// an optional sign and digits) and an optional '%' at the end. Anything else, including spaces and
+// the special values accepted by ParseString, is an error.
+//
+// Unlike ParseString, numbers that can't be stored without losing a non-zero digit are an error.
+// Valid numbers are parsed to the same Decimal as ParseString.
+//
+// Examples:
+//  1. "-123.45e-2" parses as -12345 * 10 ^ -4
+//  2. "12%" parses as 12 * 10 ^ -2
+//  3. "12abc3" fails with "unexpected character 'a'" at offset 2
+//  4. "1.2.3" fails with "unexpected second decimal point" at offset 3
+func ParseStrict(numberStr string) (Decimal, error) {
+	fail := func(offset int, err error, reason string, args ...any) (Decimal, error) {
+		return Decimal{}, &ParseError{Input: numberStr, Offset: offset, Reason: fmt.Sprintf(reason, args...), Err: err}
+	}
+	if len(numberStr) == 0 {
+		return fail(0, ErrorParsingSyntax, "empty string")
+	}
+	decimal := Decimal{Sign: true}
+	i := 0
+	// Sign
+	if numberStr[0] == '+' || numberStr[0] == '-' {
+		decimal.Sign = numberStr[0] == '+'
+		i++
+	}
+	// Value (whole + decimal part)
+	digits := uint64(0)
+	hasDigits, fraction := false, false
+	for ; i < len(numberStr); i++ {
+		c := numberStr[i]
+		if c == '.' {
+			if fraction {
+				return fail(i, ErrorParsingSyntax, "unexpected second decimal point")
+			}
+			fraction = true
+			continue
+		}
+		if c < '0' || '9' < c {
+			break
+		}
+		hasDigits = true
+		digit := uint64(c - '0')
+		switch {
+		case digits == 0 && digit == 0:
+			// Leading zeroes don't count
+			if fraction {
+				decimal.PowerOfTen--
+			}
+		case digits < digitsCutoff || (!overflow_multiplication(decimal.Value, 10) && !overflow_sum(decimal.Value*10, digit)):
+			digits++
+			if fraction {
+				decimal.PowerOfTen--
+			}
+			decimal.Value = decimal.Value*10 + digit
+		case digit != 0:
+			return fail(i, ErrorParsingPrecision, "too many significant digits")
+		case !fraction:
+			// Trailing zeroes of the whole part are stored in the power of ten
+			decimal.PowerOfTen++
+		}
+		// Note: trailing zeroes of the decimal part that don't fit are dropped, the number is still exact
+	}
+	if !hasDigits {
+		return fail(i, ErrorParsingSyntax, "missing digits")
+	}
+	// Power of 10 ('E'/'e')
+	powerOfTen := int64(0)
+	if i < len(numberStr) && (numberStr[i] == 'e' || numberStr[i] == 'E') {
+		i++
+		start, positive := i, true
+		if i < len(numberStr) && (numberStr[i] == '+' || numberStr[i] == '-') {
+			positive = numberStr[i] == '+'
+			i++
+		}
+		hasDigits = false
+		for ; i < len(numberStr) && '0' <= numberStr[i] && numberStr[i] <= '9'; i++ {
+			hasDigits = true
+			if powerOfTen > (math.MaxInt64-int64(numberStr[i]-'0'))/10 {
+				return fail(start, ErrorParsingOverflow, "exponent is too large")
+			}
+			powerOfTen = powerOfTen*10 + int64(numberStr[i]-'0')
+		}
+		if !hasDigits {
+			return fail(i, ErrorParsingSyntax, "missing exponent digits")
+		}
+		if !positive {
+			powerOfTen = -powerOfTen
+		}
+	}
+	// Percentage
+	if i < len(numberStr) && numberStr[i] == '%' {
+		i++
+		// 1% == 0.01 == 1e-2
+		decimal.PowerOfTen -= 2
+	}
+	if i < len(numberStr) {
+		return fail(i, ErrorParsingSyntax, "unexpected character %q", numberStr[i])
+	}
+	if decimal.Value == 0 {
+		return decimal, nil
+	}
+	// Merge powers of 10
+	if (powerOfTen < 0 && decimal.PowerOfTen < 0 && powerOfTen < math.MinInt64-decimal.PowerOfTen) ||
+		(powerOfTen > 0 && decimal.PowerOfTen > 0 && math.MaxInt64-decimal.PowerOfTen < powerOfTen) {
+		return fail(len(numberStr), ErrorParsingOverflow, "exponent is too large")
+	}
+	decimal.PowerOfTen += powerOfTen
+	return decimal, nil
+}
@@ -0,0 +1,123 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+func TestParseStrict(t *testing.T) {
+	testCases := map[string]struct {
+		decimal decimal.Decimal
+		offset  int
+		err     error
+	}{
+		"0":                         {decimal.Decimal{Sign: true}, 0, nil},
+		"-0":                        {decimal.Decimal{Sign: false}, 0, nil},
+		"0.00":                      {decimal.Decimal{Sign: true, PowerOfTen: -2}, 0, nil},
+		"123":                       {decimal.Decimal{Sign: true, Value: 123}, 0, nil},
+		"+1.5":                      {decimal.Decimal{Sign: true, Value: 15, PowerOfTen: -1}, 0, nil},
+		".5":                        {decimal.Decimal{Sign: true, Value: 5, PowerOfTen: -1}, 0, nil},
+		"5.":                        {decimal.Decimal{Sign: true, Value: 5}, 0, nil},
+		"-123.45e-2":                {decimal.Decimal{Sign: false, Value: 12345, PowerOfTen: -4}, 0, nil},
+		"1E+10":                     {decimal.Decimal{Sign: true, Value: 1, PowerOfTen: 10}, 0, nil},
+		"12%":                       {decimal.Decimal{Sign: true, Value: 12, PowerOfTen: -2}, 0, nil},
+		"1.5e3%":                    {decimal.Decimal{Sign: true, Value: 15, PowerOfTen: 0}, 0, nil},
+		"0000000000000000000001":    {decimal.Decimal{Sign: true, Value: 1}, 0, nil},
+		"18446744073709551615":      {decimal.Decimal{Sign: true, Value: math.MaxUint64}, 0, nil},
+		"90000000000000000000":      {decimal.Decimal{Sign: true, Value: 9000000000000000000, PowerOfTen: 1}, 0, nil},
+		"1.50000000000000000000000": {decimal.Decimal{Sign: true, Value: 15000000000000000000, PowerOfTen: -19}, 0, nil},
+		"0.0000000000000000000001":  {decimal.Decimal{Sign: true, Value: 1, PowerOfTen: -22}, 0, nil},
+		"":                          {decimal.Decimal{}, 0, decimal.ErrorParsingSyntax},
+		"-":                         {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		".":                         {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		"e5":                        {decimal.Decimal{}, 0, decimal.ErrorParsingSyntax},
+		"12abc3":                    {decimal.Decimal{}, 2, decimal.ErrorParsingSyntax},
+		"1.2.3":                     {decimal.Decimal{}, 3, decimal.ErrorParsingSyntax},
+		" 1":                        {decimal.Decimal{}, 0, decimal.ErrorParsingSyntax},
+		"1 ":                        {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		"1,000":                     {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		"--1":                       {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		"23.e":                      {decimal.Decimal{}, 4, decimal.ErrorParsingSyntax},
+		"1e+":                       {decimal.Decimal{}, 3, decimal.ErrorParsingSyntax},
+		"1e5.5":                     {decimal.Decimal{}, 3, decimal.ErrorParsingSyntax},
+		"12%%":                      {decimal.Decimal{}, 3, decimal.ErrorParsingSyntax},
+		"3/8":                       {decimal.Decimal{}, 1, decimal.ErrorParsingSyntax},
+		"NaN":                       {decimal.Decimal{}, 0, decimal.ErrorParsingSyntax},
+		"Inf":                       {decimal.Decimal{}, 0, decimal.ErrorParsingSyntax},
+		"18446744073709551619":      {decimal.Decimal{}, 19, decimal.ErrorParsingPrecision},
+		"123456789012345678901":     {decimal.Decimal{}, 20, decimal.ErrorParsingPrecision},
+		"1.000000000000000000001":   {decimal.Decimal{}, 22, decimal.ErrorParsingPrecision},
+		"1e" + strconv.FormatInt(math.MaxInt64, 10) + "0":                  {decimal.Decimal{}, 2, decimal.ErrorParsingOverflow},
+		"1000000000000000000000e" + strconv.FormatInt(math.MaxInt64-1, 10): {decimal.Decimal{}, 42, decimal.ErrorParsingOverflow},
+		"0.01e" + strconv.FormatInt(math.MinInt64+1, 10):                   {decimal.Decimal{}, 25, decimal.ErrorParsingOverflow},
+	}
+	for numberStr, expected := range testCases {
+		d, err := decimal.ParseStrict(numberStr)
+		if expected.err == nil {
+			if err != nil || d != expected.decimal {
+				t.Fatalf("ParseStrict(%q) expected %v, instead got (%v, %v)", numberStr, expected.decimal, d, err)
+			}
+			continue
+		}
+		var parseError *decimal.ParseError
+		if !errors.As(err, &parseError) || !errors.Is(err, expected.err) || parseError.Offset != expected.offset || parseError.Input != numberStr {
+			t.Fatalf("ParseStrict(%q) expected %v at offset %d, instead got (%v, %v)", numberStr, expected.err, expected.offset, d, err)
+		}
+	}
+	// The error describes the problem
+	if _, err := decimal.ParseStrict("12abc3"); err.Error() != `cannot parse "12abc3": unexpected character 'a' at offset 2` {
+		t.Fatalf("ParseStrict(\"12abc3\") returned an unexpected error message: %v", err)
+	}
+}
+
+func BenchmarkParseStrict(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		decimal.ParseStrict("-123456789.0000123456789e-12")
+	}
+}
+
+func FuzzParseStrict(f *testing.F) {
+	seeds := []string{
+		"",
+		"0",
+		".e",
+		"12abc3",
+		"1.2.3",
+		"-123.45e-2",
+		"12%",
+		"18446744073709551619",
+		"90000000000000000000",
+		"1.50000000000000000000000",
+		"1e+" + strconv.FormatInt(math.MaxInt64, 10),
+		"1000000000000000000000e" + strconv.FormatInt(math.MaxInt64-1, 10),
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, numberStr string) {
+		d, err := decimal.ParseStrict(numberStr)
+		if err != nil {
+			var parseError *decimal.ParseError
+			if !errors.As(err, &parseError) || parseError.Offset < 0 || parseError.Offset > len(numberStr) {
+				t.Fatalf("ParseStrict(%q) returned an invalid error: %v", numberStr, err)
+			}
+			return
+		}
+		// Valid numbers parse like ParseString
+		if expected, err := decimal.ParseString(numberStr); err != nil || d != expected {
+			t.Fatalf("ParseStrict(%q) returned %v, but ParseString returned (%v, %v)", numberStr, d, expected, err)
+		}
+		// Valid numbers are exact
+		if d.Value != 0 && numberStr[len(numberStr)-1] != '%' {
+			if expected, err := strconv.ParseFloat(numberStr, 64); err == nil && !math.IsInf(expected, 0) && expected != 0 {
+				if f, _ := d.Float64(); f != expected {
+					t.Fatalf("ParseStrict(%q) returned %v, expected %v", numberStr, d, expected)
+				}
+			}
+		}
+	})
+}
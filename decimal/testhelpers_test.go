@@ -0,0 +1,34 @@
+package decimal_test
+
+import (
+	"math/big"
+
+	"github.com/stefanovazzocell/GoDecimal/decimal"
+)
+
+// Converts a decimal to a big.Rat. Unlike Rat, works for any power of ten
+func toRat(d decimal.Decimal) *big.Rat {
+	return scaledRat(d.Sign, new(big.Int).SetUint64(d.Value), d.PowerOfTen)
+}
+
+// Converts a Decimal128 to a big.Rat
+func toRat128(d decimal.Decimal128) *big.Rat {
+	value := new(big.Int).Lsh(new(big.Int).SetUint64(d.High), 64)
+	return scaledRat(d.Sign, value.Or(value, new(big.Int).SetUint64(d.Low)), d.PowerOfTen)
+}
+
+// Returns ±value * 10^powerOfTen as a big.Rat
+func scaledRat(sign bool, value *big.Int, powerOfTen int64) *big.Rat {
+	r := new(big.Rat).SetInt(value)
+	magnitude := new(big.Int).Abs(big.NewInt(powerOfTen))
+	power := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), magnitude, nil))
+	if powerOfTen < 0 {
+		r.Quo(r, power)
+	} else {
+		r.Mul(r, power)
+	}
+	if !sign {
+		r.Neg(r)
+	}
+	return r
+}
@@ -4,17 +4,41 @@ import "math"
 
 // Returns true if the number is zero
 func (d Decimal) IsZero() bool {
-	return d.Value == 0
+	return d.Value == 0 && d.IsFinite()
 }
 
-// Returns true if the number is positive
+// Returns true if the number is positive (including positive infinity)
 func (d Decimal) IsPositive() bool {
-	return d.Sign && !d.IsZero()
+	return d.Sign && !d.IsZero() && !d.IsNaN()
 }
 
-// Returns true if the number is negative
+// Returns true if the number is negative (including negative infinity)
 func (d Decimal) IsNegative() bool {
-	return !d.Sign && !d.IsZero()
+	return !d.Sign && !d.IsZero() && !d.IsNaN()
+}
+
+// Returns true if the number is NaN (not a number)
+func (d Decimal) IsNaN() bool {
+	return d.Value == 0 && d.PowerOfTen == nanPowerOfTen
+}
+
+// Returns true if the number is positive or negative infinity
+func (d Decimal) IsInf() bool {
+	return d.Value == 0 && d.PowerOfTen == infinityPowerOfTen
+}
+
+// Returns true if the number is neither NaN nor infinite
+func (d Decimal) IsFinite() bool {
+	return d.Value != 0 || (d.PowerOfTen != nanPowerOfTen && d.PowerOfTen != infinityPowerOfTen)
+}
+
+// Returns d, unless it's a zero with one of the powers of ten that mark NaN and infinities:
+// in that case returns the same zero with a PowerOfTen of 0
+func asFinite(d Decimal) Decimal {
+	if !d.IsFinite() {
+		d.PowerOfTen = 0
+	}
+	return d
 }
 
 // Returns a clone of a number
@@ -45,9 +69,9 @@ func (d *Decimal) Zero() {
 }
 
 // Increase the Value of the number compensating by adjusting it's power of ten.
-// Will not affect accuracy or precision.
+// Will not affect accuracy or precision, or special values.
 func (d *Decimal) Expand() {
-	if d == nil {
+	if d == nil || !d.IsFinite() {
 		return
 	} else if d.Value == 0 {
 		d.PowerOfTen = 0
@@ -60,9 +84,9 @@ func (d *Decimal) Expand() {
 }
 
 // Compress the Value of the number compensating by adjusting it's power of ten.
-// Will not affect accuracy or precision.
+// Will not affect accuracy or precision, or special values.
 func (d *Decimal) Compress() {
-	if d == nil {
+	if d == nil || !d.IsFinite() {
 		return
 	} else if d.Value == 0 {
 		d.PowerOfTen = 0
@@ -1,6 +1,7 @@
 package decimal_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stefanovazzocell/GoDecimal/decimal"
@@ -148,3 +149,57 @@ func FuzzUtils(f *testing.F) {
 		}
 	})
 }
+
+func TestSpecialValues(t *testing.T) {
+	testCases := map[decimal.Decimal]struct {
+		isNaN, isInf, isFinite, isZero, isPositive, isNegative bool
+	}{
+		decimal.NaN():                          {isNaN: true},
+		decimal.Inf(true):                      {isInf: true, isPositive: true},
+		decimal.Inf(false):                     {isInf: true, isNegative: true},
+		{Sign: true, Value: 1}:                 {isFinite: true, isPositive: true},
+		{Sign: false, Value: 0}:                {isFinite: true, isZero: true},
+		{Sign: false, Value: 1, PowerOfTen: 3}: {isFinite: true, isNegative: true},
+		// NaN and infinities are zeroes with the largest and smallest powers of ten
+		{Sign: false, PowerOfTen: math.MaxInt64}:          {isNaN: true},
+		{Sign: true, PowerOfTen: math.MinInt64}:           {isInf: true, isPositive: true},
+		{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}: {isFinite: true, isPositive: true},
+		{Sign: true, Value: 1, PowerOfTen: math.MinInt64}: {isFinite: true, isPositive: true},
+	}
+	for testNumber, expected := range testCases {
+		if testNumber.IsNaN() != expected.isNaN || testNumber.IsInf() != expected.isInf ||
+			testNumber.IsFinite() != expected.isFinite || testNumber.IsPositive() != expected.isPositive ||
+			testNumber.IsNegative() != expected.isNegative || testNumber.IsZero() != expected.isZero {
+			t.Errorf("Unexpected classification of %v, expected %+v", testNumber, expected)
+		}
+		// Cloning keeps the special value
+		if cloned := testNumber.Clone(); cloned != testNumber {
+			t.Errorf("%v Clone() incorrectly returned %v", testNumber, cloned)
+		}
+		// Expand and Compress are noops
+		expanded, compressed := testNumber, testNumber
+		expanded.Expand()
+		compressed.Compress()
+		if !expected.isFinite && (expanded != testNumber || compressed != testNumber) {
+			t.Errorf("Expected Expand() and Compress() to leave %v untouched, instead got %v and %v", testNumber, expanded, compressed)
+		}
+		// Zero() turns it into a finite zero
+		testNumber.Zero()
+		if !testNumber.IsZero() || !testNumber.IsFinite() {
+			t.Errorf("Failed to Zero() decimal: got %v", testNumber)
+		}
+	}
+	// Zeroes with the powers of ten of NaN and infinities are never produced
+	rounded := decimal.Decimal{Sign: true, Value: 5}
+	rounded.Round(-math.MaxInt64, decimal.RoundDown)
+	quantized := decimal.Decimal{Sign: true}
+	quantized.Quantize(decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MaxInt64}, decimal.RoundHalfEven)
+	tiny := decimal.Decimal{Sign: true, Value: 10, PowerOfTen: math.MinInt64}
+	remainder, _ := decimal.Rem(tiny, decimal.Decimal{Sign: true, Value: 1, PowerOfTen: math.MinInt64 + 1})
+	otherRemainder, _ := decimal.Rem(tiny, decimal.Decimal{Sign: true, Value: 5, PowerOfTen: math.MinInt64})
+	for _, zero := range []decimal.Decimal{rounded, quantized, remainder, otherRemainder} {
+		if !zero.IsZero() {
+			t.Errorf("Expected a zero, instead got %v", zero)
+		}
+	}
+}
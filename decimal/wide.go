@@ -0,0 +1,395 @@
+package decimal
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// An unsigned 128 bit integer, used for intermediate results that might not fit in a uint64
+type uint128 struct {
+	hi, lo uint64
+}
+
+// All the powers of ten that can be represented in a uint128
+var powersOfTen128 = func() (powers [39]uint128) {
+	powers[0] = uint128{lo: 1}
+	for i := 1; i < len(powers); i++ {
+		powers[i] = powers[i-1].mul64(10)
+	}
+	return powers
+}()
+
+// Returns -1 if u < v, 0 if u == v, +1 if u > v
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi || (u.hi == v.hi && u.lo < v.lo):
+		return -1
+	case u.hi > v.hi || u.lo > v.lo:
+		return 1
+	}
+	return 0
+}
+
+// Returns true if u is zero
+func (u uint128) isZero() bool {
+	return u.hi == 0 && u.lo == 0
+}
+
+// Returns u + v, the caller must make sure the result does not overflow
+func (u uint128) add64(v uint64) uint128 {
+	lo, carry := bits.Add64(u.lo, v, 0)
+	return uint128{hi: u.hi + carry, lo: lo}
+}
+
+// Returns u + v, the caller must make sure the result does not overflow
+func (u uint128) add(v uint128) uint128 {
+	lo, carry := bits.Add64(u.lo, v.lo, 0)
+	return uint128{hi: u.hi + v.hi + carry, lo: lo}
+}
+
+// Returns u - v, the caller must make sure that u >= v
+func (u uint128) sub(v uint128) uint128 {
+	lo, borrow := bits.Sub64(u.lo, v.lo, 0)
+	return uint128{hi: u.hi - v.hi - borrow, lo: lo}
+}
+
+// Returns u * v, the caller must make sure the result does not overflow
+func (u uint128) mul64(v uint64) uint128 {
+	hi, lo := bits.Mul64(u.lo, v)
+	return uint128{hi: u.hi*v + hi, lo: lo}
+}
+
+// Returns u * 10^digits, the caller must make sure the result does not overflow
+func (u uint128) shiftLeft(digits uint64) uint128 {
+	for digits >= uint64(len(powersOfTen)) {
+		u = u.mul64(powersOfTen[len(powersOfTen)-1])
+		digits -= uint64(len(powersOfTen)) - 1
+	}
+	return u.mul64(powersOfTen[digits])
+}
+
+// Returns u / v and u % v
+func (u uint128) divmod64(v uint64) (uint128, uint64) {
+	if u.hi < v {
+		lo, remainder := bits.Div64(u.hi, u.lo, v)
+		return uint128{lo: lo}, remainder
+	}
+	quotient := uint128{hi: u.hi / v}
+	var remainder uint64
+	quotient.lo, remainder = bits.Div64(u.hi%v, u.lo, v)
+	return quotient, remainder
+}
+
+// Returns the number of decimal digits of u (0 for zero)
+func (u uint128) digits() uint64 {
+	length := bits.Len64(u.hi) + 64
+	if u.hi == 0 {
+		length = bits.Len64(u.lo)
+	}
+	// Approximation of log10(2^length), the actual number of digits is either this or the next one
+	digits := uint64(length) * 1233 >> 12
+	if u.cmp(powersOfTen128[digits]) >= 0 {
+		digits++
+	}
+	return digits
+}
+
+// Discards the last `digits` digits of u, returning the truncated result,
+// the last discarded digit, and true if any other discarded digit was non-zero.
+func (u uint128) shiftDigits(digits uint64) (quotient uint128, last uint64, sticky bool) {
+	if digits == 0 {
+		return u, 0, false
+	}
+	if digits > uint64(len(powersOfTen128)) {
+		return uint128{}, 0, !u.isZero()
+	}
+	var remainder uint64
+	for digits >= uint64(len(powersOfTen)) {
+		u, remainder = u.divmod64(powersOfTen[len(powersOfTen)-1])
+		sticky = sticky || remainder != 0
+		digits -= uint64(len(powersOfTen)) - 1
+	}
+	quotient, remainder = u.divmod64(powersOfTen[digits])
+	last, remainder = remainder/powersOfTen[digits-1], remainder%powersOfTen[digits-1]
+	return quotient, last, sticky || remainder != 0
+}
+
+// Returns the decimal representation of u
+func (u uint128) String() string {
+	if u.hi == 0 {
+		return strconv.FormatUint(u.lo, 10)
+	}
+	// Split u in its last 19 digits and the rest
+	quotient, remainder := u.divmod64(powersOfTen[len(powersOfTen)-1])
+	last := strconv.FormatUint(remainder, 10)
+	return quotient.String() + strings.Repeat("0", len(powersOfTen)-1-len(last)) + last
+}
+
+// Returns (n2, n1, n0) / v and (n2, n1, n0) % v where (n2, n1, n0) is a 192 bit number.
+// The caller must make sure that v.hi != 0 and (n2, n1) < v so that the quotient fits in a uint64.
+func divmod192(n2, n1, n0 uint64, v uint128) (uint64, uint128) {
+	// Normalize the divisor so its highest bit is set, the quotient won't change
+	// Note: shifting a uint64 by 64 or more bits in Go results in 0
+	shift := uint(bits.LeadingZeros64(v.hi))
+	v = uint128{hi: v.hi<<shift | v.lo>>(64-shift), lo: v.lo << shift}
+	n2, n1, n0 = n2<<shift|n1>>(64-shift), n1<<shift|n0>>(64-shift), n0<<shift
+	// Estimate the quotient from the highest digits, this is at most 2 more than the actual one
+	quotient := uint64(math.MaxUint64)
+	if n2 < v.hi {
+		quotient, _ = bits.Div64(n2, n1, v.hi)
+	}
+	// Compute quotient * v and correct the estimate
+	p1, p0 := bits.Mul64(quotient, v.lo)
+	p2, lo := bits.Mul64(quotient, v.hi)
+	p1, carry := bits.Add64(p1, lo, 0)
+	p2 += carry
+	for p2 > n2 || (p2 == n2 && (p1 > n1 || (p1 == n1 && p0 > n0))) {
+		quotient--
+		var borrow uint64
+		p0, borrow = bits.Sub64(p0, v.lo, 0)
+		p1, borrow = bits.Sub64(p1, v.hi, borrow)
+		p2 -= borrow
+	}
+	// The remainder is smaller than v, so it fits in 128 bits
+	r0, borrow := bits.Sub64(n0, p0, 0)
+	r1, _ := bits.Sub64(n1, p1, borrow)
+	return quotient, uint128{hi: r1 >> shift, lo: r0>>shift | r1<<(64-shift)}
+}
+
+// An unsigned 256 bit integer, used for intermediate results that might not fit in a uint128.
+// The words are stored from the least to the most significant.
+type uint256 [4]uint64
+
+// All the powers of ten that can be represented in a uint256
+var powersOfTen256 = func() (powers [78]uint256) {
+	powers[0] = uint256{1}
+	for i := 1; i < len(powers); i++ {
+		powers[i] = powers[i-1].mul64(10)
+	}
+	return powers
+}()
+
+// Returns a uint256 with the value of u
+func (u uint128) wide() uint256 {
+	return uint256{u.lo, u.hi}
+}
+
+// Returns u as a uint128, the caller must make sure it fits
+func (u uint256) narrow() uint128 {
+	return uint128{hi: u[1], lo: u[0]}
+}
+
+// Returns u * v
+func (u uint128) mul(v uint128) uint256 {
+	h0, l0 := bits.Mul64(u.lo, v.lo)
+	h1, l1 := bits.Mul64(u.lo, v.hi)
+	h2, l2 := bits.Mul64(u.hi, v.lo)
+	h3, l3 := bits.Mul64(u.hi, v.hi)
+	var result uint256
+	var carry, carry2 uint64
+	result[0] = l0
+	result[1], carry = bits.Add64(h0, l1, 0)
+	result[1], carry2 = bits.Add64(result[1], l2, 0)
+	result[2], carry = bits.Add64(h1, h2, carry)
+	result[3] = h3 + carry
+	result[2], carry = bits.Add64(result[2], l3, carry2)
+	result[3] += carry
+	return result
+}
+
+// Returns -1 if u < v, 0 if u == v, +1 if u > v
+func (u uint256) cmp(v uint256) int {
+	for i := len(u) - 1; i >= 0; i-- {
+		switch {
+		case u[i] < v[i]:
+			return -1
+		case u[i] > v[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Returns true if u is zero
+func (u uint256) isZero() bool {
+	return u[0]|u[1]|u[2]|u[3] == 0
+}
+
+// Returns u + v, the caller must make sure the result does not overflow
+func (u uint256) add(v uint256) uint256 {
+	var carry uint64
+	for i := range u {
+		u[i], carry = bits.Add64(u[i], v[i], carry)
+	}
+	return u
+}
+
+// Returns u - v, the caller must make sure that u >= v
+func (u uint256) sub(v uint256) uint256 {
+	var borrow uint64
+	for i := range u {
+		u[i], borrow = bits.Sub64(u[i], v[i], borrow)
+	}
+	return u
+}
+
+// Returns u * v, the caller must make sure the result does not overflow
+func (u uint256) mul64(v uint64) uint256 {
+	var carry uint64
+	for i := range u {
+		hi, lo := bits.Mul64(u[i], v)
+		var overflow uint64
+		u[i], overflow = bits.Add64(lo, carry, 0)
+		carry = hi + overflow
+	}
+	return u
+}
+
+// Returns u * 10^digits, the caller must make sure the result does not overflow
+func (u uint256) shiftLeft(digits uint64) uint256 {
+	for digits >= uint64(len(powersOfTen)) {
+		u = u.mul64(powersOfTen[len(powersOfTen)-1])
+		digits -= uint64(len(powersOfTen)) - 1
+	}
+	return u.mul64(powersOfTen[digits])
+}
+
+// Returns u / v and u % v
+func (u uint256) divmod64(v uint64) (uint256, uint64) {
+	var remainder uint64
+	for i := len(u) - 1; i >= 0; i-- {
+		u[i], remainder = bits.Div64(remainder, u[i], v)
+	}
+	return u, remainder
+}
+
+// Returns u / v and u % v, the caller must make sure that v is not zero
+func (u uint256) divmod128(v uint128) (uint256, uint128) {
+	if v.hi == 0 {
+		quotient, remainder := u.divmod64(v.lo)
+		return quotient, uint128{lo: remainder}
+	}
+	// Schoolbook division, one 64 bit word at a time.
+	// Note: the remainder is always smaller than v so every quotient word fits in a uint64
+	var remainder uint128
+	for i := len(u) - 1; i >= 0; i-- {
+		u[i], remainder = divmod192(remainder.hi, remainder.lo, u[i], v)
+	}
+	return u, remainder
+}
+
+// Returns the number of decimal digits of u (0 for zero)
+func (u uint256) digits() uint64 {
+	length := 0
+	for i := len(u) - 1; i >= 0; i-- {
+		if u[i] != 0 {
+			length = 64*i + bits.Len64(u[i])
+			break
+		}
+	}
+	// Approximation of log10(2^length), the actual number of digits is either this or the next one
+	digits := uint64(length) * 1233 >> 12
+	if digits < uint64(len(powersOfTen256)) && u.cmp(powersOfTen256[digits]) >= 0 {
+		digits++
+	}
+	return digits
+}
+
+// Discards the last `digits` digits of u, returning the truncated result,
+// the last discarded digit, and true if any other discarded digit was non-zero.
+func (u uint256) shiftDigits(digits uint64) (quotient uint256, last uint64, sticky bool) {
+	if digits == 0 {
+		return u, 0, false
+	}
+	if digits > uint64(len(powersOfTen256)) {
+		return uint256{}, 0, !u.isZero()
+	}
+	var remainder uint64
+	for digits >= uint64(len(powersOfTen)) {
+		u, remainder = u.divmod64(powersOfTen[len(powersOfTen)-1])
+		sticky = sticky || remainder != 0
+		digits -= uint64(len(powersOfTen)) - 1
+	}
+	quotient, remainder = u.divmod64(powersOfTen[digits])
+	last, remainder = remainder/powersOfTen[digits-1], remainder%powersOfTen[digits-1]
+	return quotient, last, sticky || remainder != 0
+}
+
+// A power of ten that might temporarily exceed the int64 range during calculations,
+// stored as a 128 bit two's complement integer
+type widePower struct {
+	hi int64
+	lo uint64
+}
+
+// Returns a widePower for the given power of ten
+func newWidePower(p int64) widePower {
+	return widePower{hi: p >> 63, lo: uint64(p)}
+}
+
+// Returns w + p
+func (w widePower) add(p int64) widePower {
+	lo, carry := bits.Add64(w.lo, uint64(p), 0)
+	return widePower{hi: w.hi + p>>63 + int64(carry), lo: lo}
+}
+
+// Returns w - p
+func (w widePower) sub(p int64) widePower {
+	lo, borrow := bits.Sub64(w.lo, uint64(p), 0)
+	return widePower{hi: w.hi - p>>63 - int64(borrow), lo: lo}
+}
+
+// Returns the power of ten clamped to the int64 range, alongside how far below
+// math.MinInt64 it is (saturating at math.MaxUint64) and true if it's above math.MaxInt64
+func (w widePower) clamp() (power int64, under uint64, over bool) {
+	return w.clampTo(math.MinInt64, math.MaxInt64)
+}
+
+// Returns the power of ten clamped to the [min, max] range, alongside how far below
+// min it is (saturating at math.MaxUint64) and true if it's above max
+func (w widePower) clampTo(min, max int64) (power int64, under uint64, over bool) {
+	if lower := newWidePower(min); w.less(lower) {
+		distance := lower.subWide(w)
+		if distance.hi != 0 {
+			return min, math.MaxUint64, false
+		}
+		return min, distance.lo, false
+	}
+	if newWidePower(max).less(w) {
+		return max, 0, true
+	}
+	return int64(w.lo), 0, false
+}
+
+// Returns w + v
+func (w widePower) addWide(v widePower) widePower {
+	lo, carry := bits.Add64(w.lo, v.lo, 0)
+	return widePower{hi: w.hi + v.hi + int64(carry), lo: lo}
+}
+
+// Returns p * m, which always fits in a widePower
+func mulWidePower(p int64, m uint64) widePower {
+	magnitude := uint64(p)
+	if p < 0 {
+		magnitude = -magnitude
+	}
+	hi, lo := bits.Mul64(magnitude, m)
+	product := widePower{hi: int64(hi), lo: lo}
+	if p < 0 {
+		return widePower{}.subWide(product)
+	}
+	return product
+}
+
+// Returns w - v
+func (w widePower) subWide(v widePower) widePower {
+	lo, borrow := bits.Sub64(w.lo, v.lo, 0)
+	return widePower{hi: w.hi - v.hi - int64(borrow), lo: lo}
+}
+
+// Returns true if w < v
+func (w widePower) less(v widePower) bool {
+	return w.hi < v.hi || (w.hi == v.hi && w.lo < v.lo)
+}
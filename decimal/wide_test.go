@@ -0,0 +1,162 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestUint128(t *testing.T) {
+	// digits
+	for i, power := range powersOfTen128 {
+		if actual := power.digits(); actual != uint64(i+1) {
+			t.Fatalf("Expected 10^%d to have %d digits, instead got %d", i, i+1, actual)
+		}
+		if i == 0 {
+			continue
+		}
+		if actual := (uint128{hi: power.hi, lo: power.lo - 1}).digits(); actual != uint64(i) {
+			t.Fatalf("Expected 10^%d-1 to have %d digits, instead got %d", i, i, actual)
+		}
+	}
+	if actual := (uint128{}).digits(); actual != 0 {
+		t.Fatalf("Expected 0 to have no digits, instead got %d", actual)
+	}
+	if actual := (uint128{hi: math.MaxUint64, lo: math.MaxUint64}).digits(); actual != 39 {
+		t.Fatalf("Expected the largest uint128 to have 39 digits, instead got %d", actual)
+	}
+	// shiftDigits
+	testCases := map[struct {
+		value  uint128
+		digits uint64
+	}]struct {
+		quotient uint128
+		last     uint64
+		sticky   bool
+	}{
+		{uint128{lo: 12345}, 0}:                               {uint128{lo: 12345}, 0, false},
+		{uint128{lo: 12345}, 1}:                               {uint128{lo: 1234}, 5, false},
+		{uint128{lo: 12345}, 2}:                               {uint128{lo: 123}, 4, true},
+		{uint128{lo: 12500}, 3}:                               {uint128{lo: 12}, 5, false},
+		{uint128{lo: 12345}, 5}:                               {uint128{}, 1, true},
+		{uint128{lo: 12345}, 6}:                               {uint128{}, 0, true},
+		{uint128{lo: 12345}, 100}:                             {uint128{}, 0, true},
+		{uint128{}, 100}:                                      {uint128{}, 0, false},
+		{powersOfTen128[38].mul64(3), 38}:                     {uint128{lo: 3}, 0, false},
+		{powersOfTen128[38].mul64(3).add64(1), 38}:            {uint128{lo: 3}, 0, true},
+		{powersOfTen128[38].mul64(3).add64(1), 39}:            {uint128{}, 3, true},
+		{uint128{hi: math.MaxUint64, lo: math.MaxUint64}, 20}: {uint128{lo: 3402823669209384634}, 6, true},
+	}
+	for test, expected := range testCases {
+		quotient, last, sticky := test.value.shiftDigits(test.digits)
+		if quotient != expected.quotient || last != expected.last || sticky != expected.sticky {
+			t.Fatalf("Expected %v shifted by %d digits to return (%v, %d, %v), instead got (%v, %d, %v)",
+				test.value, test.digits,
+				expected.quotient, expected.last, expected.sticky,
+				quotient, last, sticky)
+		}
+	}
+}
+
+func TestUint256(t *testing.T) {
+	// Converts a number (words from the least significant) to a big.Int
+	toBig := func(words ...uint64) *big.Int {
+		result := new(big.Int)
+		for i := len(words) - 1; i >= 0; i-- {
+			result.Lsh(result, 64)
+			result.Or(result, new(big.Int).SetUint64(words[i]))
+		}
+		return result
+	}
+	// digits
+	for i, power := range powersOfTen256 {
+		if actual := power.digits(); actual != uint64(i+1) {
+			t.Fatalf("Expected 10^%d to have %d digits, instead got %d", i, i+1, actual)
+		}
+		if i == 0 {
+			continue
+		}
+		if actual := power.sub(uint256{1}).digits(); actual != uint64(i) {
+			t.Fatalf("Expected 10^%d-1 to have %d digits, instead got %d", i, i, actual)
+		}
+	}
+	if actual := (uint256{}).digits(); actual != 0 {
+		t.Fatalf("Expected 0 to have no digits, instead got %d", actual)
+	}
+	// Compare operations on random numbers against math/big
+	random := rand.New(rand.NewSource(0))
+	randomWord := func() uint64 {
+		// Favour edge cases
+		switch random.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return math.MaxUint64 - uint64(random.Intn(2))
+		}
+		return random.Uint64()
+	}
+	for i := 0; i < 10000; i++ {
+		u := uint128{hi: randomWord(), lo: randomWord()}
+		v := uint128{hi: randomWord(), lo: randomWord()}
+		if u.String() != toBig(u.lo, u.hi).String() {
+			t.Fatalf("Expected %v to be formatted as %s, instead got %s", u, toBig(u.lo, u.hi), u.String())
+		}
+		product := u.mul(v)
+		expected := new(big.Int).Mul(toBig(u.lo, u.hi), toBig(v.lo, v.hi))
+		if toBig(product[:]...).Cmp(expected) != 0 {
+			t.Fatalf("Expected %v * %v to be %s, instead got %s", u, v, expected, toBig(product[:]...))
+		}
+		if v.isZero() {
+			continue
+		}
+		quotient, remainder := product.add(u.wide()).divmod128(v)
+		expectedQuotient, expectedRemainder := new(big.Int).QuoRem(expected.Add(expected, toBig(u.lo, u.hi)), toBig(v.lo, v.hi), new(big.Int))
+		if toBig(quotient[:]...).Cmp(expectedQuotient) != 0 || toBig(remainder.lo, remainder.hi).Cmp(expectedRemainder) != 0 {
+			t.Fatalf("Expected %v / %v to be (%s, %s), instead got (%s, %s)", product, v,
+				expectedQuotient, expectedRemainder, toBig(quotient[:]...), toBig(remainder.lo, remainder.hi))
+		}
+		digits := uint64(random.Intn(80))
+		shifted, last, sticky := product.shiftDigits(digits)
+		if digits == 0 {
+			if shifted != product || last != 0 || sticky {
+				t.Fatalf("Expected shifting %v by 0 digits to do nothing", product)
+			}
+			continue
+		}
+		expectedShifted, rest := new(big.Int).QuoRem(toBig(product[:]...), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil), new(big.Int))
+		expectedLast, rest := rest.QuoRem(rest, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits-1)), nil), new(big.Int))
+		if toBig(shifted[:]...).Cmp(expectedShifted) != 0 || last != expectedLast.Uint64() || sticky != (rest.Sign() != 0) {
+			t.Fatalf("Expected %v shifted by %d digits to return (%s, %s, %v), instead got (%v, %d, %v)", product, digits,
+				expectedShifted, expectedLast, rest.Sign() != 0, shifted, last, sticky)
+		}
+	}
+}
+
+func TestWidePower(t *testing.T) {
+	testCases := map[widePower]struct {
+		power int64
+		under uint64
+		over  bool
+	}{
+		newWidePower(0):                                                           {0, 0, false},
+		newWidePower(-5):                                                          {-5, 0, false},
+		newWidePower(math.MaxInt64):                                               {math.MaxInt64, 0, false},
+		newWidePower(math.MinInt64):                                               {math.MinInt64, 0, false},
+		newWidePower(math.MaxInt64).add(1):                                        {math.MaxInt64, 0, true},
+		newWidePower(math.MinInt64).sub(1):                                        {math.MinInt64, 1, false},
+		newWidePower(math.MinInt64).add(math.MinInt64):                            {math.MinInt64, 1 << 63, false},
+		newWidePower(math.MinInt64).sub(math.MaxInt64):                            {math.MinInt64, math.MaxInt64, false},
+		newWidePower(0).sub(math.MinInt64):                                        {math.MaxInt64, 0, true},
+		newWidePower(-1).sub(math.MinInt64):                                       {math.MaxInt64, 0, false},
+		newWidePower(math.MaxInt64).add(math.MaxInt64):                            {math.MaxInt64, 0, true},
+		newWidePower(math.MinInt64).add(math.MinInt64).sub(math.MaxInt64).sub(10): {math.MinInt64, math.MaxUint64, false},
+	}
+	for test, expected := range testCases {
+		power, under, over := test.clamp()
+		if power != expected.power || under != expected.under || over != expected.over {
+			t.Fatalf("Expected %v to clamp to (%d, %d, %v), instead got (%d, %d, %v)",
+				test, expected.power, expected.under, expected.over, power, under, over)
+		}
+	}
+}